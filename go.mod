@@ -3,9 +3,14 @@ module robot-operator
 go 1.16
 
 require (
+	github.com/google/cel-go v0.7.3
+	github.com/prometheus/client_golang v1.7.1
+	github.com/robfig/cron/v3 v3.0.1
+	google.golang.org/genproto v0.0.0-20201102152239-715cce707fb0
 	k8s.io/api v0.20.5
 	k8s.io/apimachinery v0.20.5
 	k8s.io/client-go v0.20.0
 	k8s.io/code-generator v0.20.0
 	k8s.io/klog/v2 v2.4.0
+	sigs.k8s.io/yaml v1.2.0
 )
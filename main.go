@@ -2,16 +2,23 @@ package main
 
 import (
 	"flag"
+	"net/http"
 	"time"
+	// schedules name their time zone, which the image may not ship
+	_ "time/tzdata"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/klog/v2"
 
+	"robot-operator/pkg/config"
 	"robot-operator/pkg/controller"
 	clientset "robot-operator/pkg/generated/clientset/versioned"
 	robotinformers "robot-operator/pkg/generated/informers/externalversions"
+	"robot-operator/pkg/prometheus"
 	"robot-operator/pkg/signals"
 )
 
@@ -20,8 +27,16 @@ const (
 )
 
 var (
-	kubeConfig string
-	masterURL  string
+	kubeConfig        string
+	masterURL         string
+	prometheusURL     string
+	configFile        string
+	activationAddress string
+	webhookAddress    string
+	metricsAddress    string
+	tlsCertFile       string
+	tlsKeyFile        string
+	dryRun            bool
 )
 
 func main() {
@@ -31,6 +46,11 @@ func main() {
 	// setup signals
 	stopCh := signals.SetupSignalHandler()
 
+	cfg, err := config.Load(configFile)
+	if err != nil {
+		klog.Fatalf("Error loading operator config: %s", err.Error())
+	}
+
 	kubeCfg, err := clientcmd.BuildConfigFromFlags(masterURL, kubeConfig)
 	if err != nil {
 		klog.Fatal("Error building kubeconfig: %s", err.Error())
@@ -49,13 +69,111 @@ func main() {
 	// create SharedInformerFactory
 	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(kubeClient, time.Second*30)
 	robotInformerFactory := robotinformers.NewSharedInformerFactory(robotClient, time.Second*30)
+	// only the pods of Robots are watched
+	podInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, time.Second*30,
+		kubeinformers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = controller.PodSelector()
+		}))
+
+	// rollout analysis is only available when a Prometheus endpoint is given
+	var prometheusClient prometheus.Interface
+	if prometheusURL != "" {
+		prometheusClient = prometheus.NewClient(prometheusURL)
+	}
 
-	// create Controller
-	controller := controller.NewController(kubeClient, robotClient, kubeInformerFactory.Apps().V1().Deployments(), robotInformerFactory.Robot().V1().Robots())
+	// create Controllers
+	robotSetController := controller.NewRobotSetController(kubeClient, robotClient,
+		kubeInformerFactory.Apps().V1().Deployments(),
+		robotInformerFactory.Robot().V1().Robots(),
+		robotInformerFactory.Robot().V1().RobotSets(),
+		dryRun)
+
+	robotTaskController := controller.NewRobotTaskController(kubeClient, robotClient,
+		kubeInformerFactory.Apps().V1().Deployments(),
+		kubeInformerFactory.Batch().V1().Jobs(),
+		robotInformerFactory.Robot().V1().Robots(),
+		robotInformerFactory.Robot().V1().RobotTasks(),
+		dryRun)
+
+	robotCronTaskController := controller.NewRobotCronTaskController(kubeClient, robotClient,
+		kubeInformerFactory.Apps().V1().Deployments(),
+		kubeInformerFactory.Batch().V1beta1().CronJobs(),
+		robotInformerFactory.Robot().V1().Robots(),
+		robotInformerFactory.Robot().V1().RobotCronTasks(),
+		dryRun)
+
+	controller := controller.NewController(kubeClient, robotClient,
+		kubeInformerFactory.Apps().V1().Deployments(),
+		kubeInformerFactory.Apps().V1().ReplicaSets(),
+		kubeInformerFactory.Core().V1().ConfigMaps(),
+		kubeInformerFactory.Core().V1().Secrets(),
+		kubeInformerFactory.Core().V1().ServiceAccounts(),
+		kubeInformerFactory.Core().V1().PersistentVolumeClaims(),
+		kubeInformerFactory.Rbac().V1().Roles(),
+		kubeInformerFactory.Rbac().V1().RoleBindings(),
+		kubeInformerFactory.Networking().V1().NetworkPolicies(),
+		podInformerFactory.Core().V1().Pods(),
+		robotInformerFactory.Robot().V1().Robots(),
+		robotInformerFactory.Robot().V1().RobotClasses(),
+		robotInformerFactory.Robot().V1().RobotPolicies(),
+		robotInformerFactory.Robot().V1().ClusterRobotPolicies(),
+		robotInformerFactory.Robot().V1().RobotQuotas(),
+		prometheusClient,
+		cfg,
+		dryRun)
 
 	// start Informers
 	kubeInformerFactory.Start(stopCh)
 	robotInformerFactory.Start(stopCh)
+	podInformerFactory.Start(stopCh)
+
+	// wake idle Robots on demand
+	if activationAddress != "" {
+		go func() {
+			if err := controller.ServeActivation(activationAddress, stopCh); err != nil {
+				klog.Fatalf("Error serving activation endpoint: %s", err.Error())
+			}
+		}()
+	}
+
+	// expose the operator's metrics
+	if metricsAddress != "" {
+		go func() {
+			mux := http.NewServeMux()
+			mux.Handle("/metrics", promhttp.Handler())
+			klog.Infof("Serving metrics on %s", metricsAddress)
+			if err := http.ListenAndServe(metricsAddress, mux); err != nil {
+				klog.Fatalf("Error serving metrics: %s", err.Error())
+			}
+		}()
+	}
+
+	// deny Robots failing their rules or violating their policies
+	if webhookAddress != "" {
+		go func() {
+			if err := controller.ServeWebhook(webhookAddress, tlsCertFile, tlsKeyFile, stopCh); err != nil {
+				klog.Fatalf("Error serving validating webhook: %s", err.Error())
+			}
+		}()
+	}
+
+	go func() {
+		if err := robotSetController.Run(threadness, stopCh); err != nil {
+			klog.Fatalf("Error running RobotSet controller: %s", err.Error())
+		}
+	}()
+
+	go func() {
+		if err := robotTaskController.Run(threadness, stopCh); err != nil {
+			klog.Fatalf("Error running RobotTask controller: %s", err.Error())
+		}
+	}()
+
+	go func() {
+		if err := robotCronTaskController.Run(threadness, stopCh); err != nil {
+			klog.Fatalf("Error running RobotCronTask controller: %s", err.Error())
+		}
+	}()
 
 	// run Controller
 	if err := controller.Run(threadness, stopCh); err != nil {
@@ -66,4 +184,12 @@ func main() {
 func init() {
 	flag.StringVar(&kubeConfig, "kubeconfig", "", "Path to a kubeconfig. Only required if out-of-cluster.")
 	flag.StringVar(&masterURL, "master", "", "The address of the Kubernetes API server. Overrides any value in kubeconfig. Only required if out-of-cluster.")
+	flag.StringVar(&prometheusURL, "prometheus-url", "", "The address of a Prometheus-compatible server used for rollout analysis.")
+	flag.StringVar(&configFile, "config", "", "Path to the operator config file.")
+	flag.BoolVar(&dryRun, "dry-run", false, "Log and record events for the changes the controller would make instead of making them.")
+	flag.StringVar(&activationAddress, "activation-address", "", "The address the endpoint activating idle Robots listens on, e.g. :8080. Disabled if empty.")
+	flag.StringVar(&webhookAddress, "webhook-address", "", "The address the validating admission webhook listens on, e.g. :8443. Disabled if empty.")
+	flag.StringVar(&metricsAddress, "metrics-address", "", "The address the Prometheus metrics endpoint listens on, e.g. :9090. Disabled if empty.")
+	flag.StringVar(&tlsCertFile, "tls-cert-file", "", "Path to the TLS certificate the validating admission webhook serves.")
+	flag.StringVar(&tlsKeyFile, "tls-key-file", "", "Path to the TLS private key of the validating admission webhook.")
 }
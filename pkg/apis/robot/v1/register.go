@@ -30,7 +30,16 @@ var (
 
 // Adds the list of known types to Scheme.
 func addKnownTypes(scheme *runtime.Scheme) error {
-	scheme.AddKnownTypes(SchemeGroupVersion, &Robot{}, &RobotList{})
+	scheme.AddKnownTypes(SchemeGroupVersion,
+		&Robot{}, &RobotList{},
+		&RobotSet{}, &RobotSetList{},
+		&RobotClass{}, &RobotClassList{},
+		&RobotPolicy{}, &RobotPolicyList{},
+		&ClusterRobotPolicy{}, &ClusterRobotPolicyList{},
+		&RobotQuota{}, &RobotQuotaList{},
+		&RobotTask{}, &RobotTaskList{},
+		&RobotCronTask{}, &RobotCronTaskList{},
+	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 
 	return nil
@@ -0,0 +1,51 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RobotClass holds defaults shared by the Robots referring to it through
+// spec.className.
+type RobotClass struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec RobotClassSpec `json:"spec"`
+}
+
+// RobotClassSpec is the spec for a RobotClass resource. Whatever a Robot sets
+// itself wins over its class: maps are merged key by key, the spread
+// shorthands apply if either sets them, and other fields are defaulted.
+type RobotClassSpec struct {
+	// ImageRegistry is prepended to the images of the Robots' containers
+	// which do not name a registry.
+	ImageRegistry string                       `json:"imageRegistry,omitempty"`
+	Image         string                       `json:"image,omitempty"`
+	Resources     *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	NodeSelector      map[string]string   `json:"nodeSelector,omitempty"`
+	Affinity          *corev1.Affinity    `json:"affinity,omitempty"`
+	Tolerations       []corev1.Toleration `json:"tolerations,omitempty"`
+	PriorityClassName string              `json:"priorityClassName,omitempty"`
+	SpreadAcrossZones bool                `json:"spreadAcrossZones,omitempty"`
+	SpreadAcrossNodes bool                `json:"spreadAcrossNodes,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RobotClassList is a list of RobotClass resources.
+type RobotClassList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []RobotClass `json:"items"`
+}
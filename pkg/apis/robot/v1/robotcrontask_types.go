@@ -0,0 +1,72 @@
+package v1
+
+import (
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RobotCronTask runs a command with the image and configuration of a Robot on
+// a schedule, e.g. a nightly re-index.
+type RobotCronTask struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RobotCronTaskSpec   `json:"spec"`
+	Status RobotCronTaskStatus `json:"status"`
+}
+
+// RobotCronTaskSpec is the spec for a RobotCronTask resource. The task runs as
+// a CronJob rendered from the pod template of the Deployment of the Robot,
+// kept up to date as the Robot changes.
+type RobotCronTaskSpec struct {
+	// RobotName names the Robot of the namespace the task runs as.
+	RobotName string `json:"robotName"`
+	// Schedule is a cron expression, e.g. "0 3 * * *", in the time zone of
+	// the cluster.
+	Schedule string `json:"schedule"`
+	// Command and Args override those of the Robot's container.
+	Command []string `json:"command,omitempty"`
+	Args    []string `json:"args,omitempty"`
+	// ConcurrencyPolicy tells whether a run may start while the previous one
+	// is still going: Allow, Forbid or Replace, defaulting to Allow.
+	ConcurrencyPolicy batchv1beta1.ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+	// Suspend stops scheduling runs, leaving those going alone.
+	Suspend bool `json:"suspend,omitempty"`
+	// StartingDeadlineSeconds bounds how late a missed run may still start.
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+	// SuccessfulJobsHistoryLimit and FailedJobsHistoryLimit are the numbers
+	// of finished Jobs kept, defaulting to 3 and 1.
+	SuccessfulJobsHistoryLimit *int32 `json:"successfulJobsHistoryLimit,omitempty"`
+	FailedJobsHistoryLimit     *int32 `json:"failedJobsHistoryLimit,omitempty"`
+	// BackoffLimit is the number of retries before a run fails, defaulting
+	// to 6.
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+	// ActiveDeadlineSeconds bounds how long a run may take, retries
+	// included.
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+}
+
+// RobotCronTaskStatus is the status for a RobotCronTask resource.
+type RobotCronTaskStatus struct {
+	// CronJobName names the CronJob running the task.
+	CronJobName string `json:"cronJobName,omitempty"`
+	// Active names the Jobs of the runs going on.
+	Active []string `json:"active,omitempty"`
+	// LastScheduleTime is when a run was last started.
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// Message tells why the task is not scheduled.
+	Message string `json:"message,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RobotCronTaskList is a list of RobotCronTask resources.
+type RobotCronTaskList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []RobotCronTask `json:"items"`
+}
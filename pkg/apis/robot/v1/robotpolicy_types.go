@@ -0,0 +1,71 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RobotPolicy caps what the Robots of its namespace may ask for.
+type RobotPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec RobotPolicySpec `json:"spec"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterRobotPolicy caps what every Robot may ask for.
+type ClusterRobotPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec RobotPolicySpec `json:"spec"`
+}
+
+// RobotPolicySpec is the spec for RobotPolicy and ClusterRobotPolicy
+// resources. A Robot must satisfy every policy that applies to it, after the
+// defaults of its RobotClass are merged in.
+type RobotPolicySpec struct {
+	// MaxReplicas caps spec.replicas and the replicas of the schedules.
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+	// AllowedRegistries the images of the Robot's containers may come from,
+	// e.g. "registry.example.com" or "registry.example.com/bots". Images
+	// naming no registry come from docker.io.
+	AllowedRegistries []string `json:"allowedRegistries,omitempty"`
+	// RequiredLabels are label keys every Robot must carry.
+	RequiredLabels []string `json:"requiredLabels,omitempty"`
+	// ForbidPrivileged refuses privileged containers.
+	ForbidPrivileged bool `json:"forbidPrivileged,omitempty"`
+	// ForbidHostPorts refuses containers binding ports of their node.
+	ForbidHostPorts bool `json:"forbidHostPorts,omitempty"`
+	// MaxResources caps the requests and limits of every container.
+	MaxResources corev1.ResourceList `json:"maxResources,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RobotPolicyList is a list of RobotPolicy resources.
+type RobotPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []RobotPolicy `json:"items"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterRobotPolicyList is a list of ClusterRobotPolicy resources.
+type ClusterRobotPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ClusterRobotPolicy `json:"items"`
+}
@@ -0,0 +1,50 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RobotQuota caps the Robots of its namespace and the replicas they run.
+type RobotQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RobotQuotaSpec   `json:"spec"`
+	Status RobotQuotaStatus `json:"status"`
+}
+
+// RobotQuotaSpec is the spec for a RobotQuota resource. Robots get their share
+// of the quota in the order they were created: those beyond MaxRobots run no
+// replicas, and the others run the replicas they ask for up to what the
+// Robots before them leave. Each Robot counts for the replicas its
+// Deployment runs, as its schedules and idleness decide, or for its
+// spec.replicas until it has a Deployment.
+type RobotQuotaSpec struct {
+	// MaxRobots caps the Robots of the namespace running replicas, suspended
+	// ones included.
+	MaxRobots *int32 `json:"maxRobots,omitempty"`
+	// MaxReplicas caps the replicas of the Robots of the namespace together.
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+}
+
+// RobotQuotaStatus is the status for a RobotQuota resource.
+type RobotQuotaStatus struct {
+	// Robots is the number of Robots in the namespace.
+	Robots int32 `json:"robots"`
+	// Replicas is the number of replicas the Robots of the namespace run
+	// together.
+	Replicas int32 `json:"replicas"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RobotQuotaList is a list of RobotQuota resources.
+type RobotQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []RobotQuota `json:"items"`
+}
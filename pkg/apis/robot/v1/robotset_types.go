@@ -0,0 +1,81 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RobotSet is a fleet of Robots stamped out of one template, one Robot per
+// parameter.
+type RobotSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RobotSetSpec   `json:"spec"`
+	Status RobotSetStatus `json:"status"`
+}
+
+// RobotSetSpec is the spec for a RobotSet resource.
+type RobotSetSpec struct {
+	// Template of the Robots. Its string values are Go templates executed
+	// with the Name and Namespace of the Robot, and the Name and Values of
+	// its Parameter.
+	Template RobotTemplate `json:"template"`
+	// Parameters lists the Robots of the set, named <set>-<parameter>.
+	Parameters []RobotSetParameter `json:"parameters,omitempty"`
+	// Generator generates more parameters.
+	Generator *RobotSetGenerator `json:"generator,omitempty"`
+	// UpdateStrategy limits how many Robots change at once.
+	UpdateStrategy RobotSetUpdateStrategy `json:"updateStrategy,omitempty"`
+}
+
+// RobotTemplate describes the Robots of a RobotSet.
+type RobotTemplate struct {
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Spec of the Robots. DeploymentName defaults to the name of the Robot.
+	Spec RobotSpec `json:"spec"`
+}
+
+// RobotSetParameter is what a Robot of a RobotSet differs by.
+type RobotSetParameter struct {
+	Name   string            `json:"name"`
+	Values map[string]string `json:"values,omitempty"`
+}
+
+// RobotSetGenerator generates parameters named "0" to Count-1, whose "index"
+// value is their name.
+type RobotSetGenerator struct {
+	Count int32 `json:"count"`
+}
+
+// RobotSetUpdateStrategy describes how the Robots of a RobotSet are updated.
+type RobotSetUpdateStrategy struct {
+	// MaxUpdating is how many Robots may be created or updating at once, a
+	// Robot updating until its Deployment is rolled out. Defaults to 1.
+	MaxUpdating int32 `json:"maxUpdating,omitempty"`
+}
+
+// RobotSetStatus is the status for a RobotSet resource.
+type RobotSetStatus struct {
+	// Robots is the number of Robots of the set.
+	Robots int32 `json:"robots"`
+	// UpdatedRobots is the number of Robots matching the current template.
+	UpdatedRobots int32 `json:"updatedRobots"`
+	// ReadyRobots is the number of Robots whose Deployment is rolled out.
+	ReadyRobots int32 `json:"readyRobots"`
+	// AvailableReplicas is the number of available replicas across the set.
+	AvailableReplicas int32 `json:"availableReplicas"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RobotSetList is a list of RobotSet resources.
+type RobotSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []RobotSet `json:"items"`
+}
@@ -0,0 +1,74 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RobotTask runs a one-off command with the image and configuration of a
+// Robot, e.g. a migration.
+type RobotTask struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RobotTaskSpec   `json:"spec"`
+	Status RobotTaskStatus `json:"status"`
+}
+
+// RobotTaskSpec is the spec for a RobotTask resource. The task runs once, as
+// a Job rendered from the pod template of the Deployment of the Robot, and
+// changes made to it afterwards are ignored.
+type RobotTaskSpec struct {
+	// RobotName names the Robot of the namespace the task runs as.
+	RobotName string `json:"robotName"`
+	// Command and Args override those of the Robot's container.
+	Command []string `json:"command,omitempty"`
+	Args    []string `json:"args,omitempty"`
+	// BackoffLimit is the number of retries before the task fails,
+	// defaulting to 6.
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+	// ActiveDeadlineSeconds bounds how long the task may run, retries
+	// included.
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+}
+
+// RobotTaskPhase is the phase of a RobotTask.
+type RobotTaskPhase string
+
+const (
+	// RobotTaskPending means the task waits for the Deployment of its Robot.
+	RobotTaskPending RobotTaskPhase = "Pending"
+	// RobotTaskRunning means the Job of the task is running.
+	RobotTaskRunning RobotTaskPhase = "Running"
+	// RobotTaskSucceeded means the Job of the task completed.
+	RobotTaskSucceeded RobotTaskPhase = "Succeeded"
+	// RobotTaskFailed means the Job of the task failed for good.
+	RobotTaskFailed RobotTaskPhase = "Failed"
+)
+
+// RobotTaskStatus is the status for a RobotTask resource.
+type RobotTaskStatus struct {
+	Phase RobotTaskPhase `json:"phase,omitempty"`
+	// JobName names the Job running the task.
+	JobName        string       `json:"jobName,omitempty"`
+	StartTime      *metav1.Time `json:"startTime,omitempty"`
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Retries is the number of attempts of the task which failed.
+	Retries int32 `json:"retries,omitempty"`
+	// Message tells why the task is pending or failed.
+	Message string `json:"message,omitempty"`
+	// Logs is the kubectl command printing the logs of the task.
+	Logs string `json:"logs,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RobotTaskList is a list of RobotTask resources.
+type RobotTaskList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []RobotTask `json:"items"`
+}
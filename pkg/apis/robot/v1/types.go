@@ -1,9 +1,35 @@
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// RestartedAtAnnotation triggers a rolling restart of the Robot's pods
+	// whenever its value changes, e.g. when set to the current time.
+	RestartedAtAnnotation = "robot.llleon.io/restartedAt"
+	// RegenerateSecretsAtAnnotation regenerates every value of spec.secrets
+	// whenever its value changes.
+	RegenerateSecretsAtAnnotation = "robot.llleon.io/regenerateSecretsAt"
+	// SkipDefaultSidecarsAnnotation opts a Robot out of the default sidecars
+	// configured for the operator: "true" skips all of them, otherwise its
+	// value is a comma-separated list of the sidecar names to skip.
+	SkipDefaultSidecarsAnnotation = "robot.llleon.io/skipDefaultSidecars"
+	// LastActivityAnnotation records, as an RFC 3339 time, when a Robot
+	// last served a request. Setting it wakes an idle Robot.
+	LastActivityAnnotation = "robot.llleon.io/lastActivity"
+	// PausedAnnotation pauses the reconciliation of a Robot when "true",
+	// like spec.paused.
+	PausedAnnotation = "robot.llleon.io/paused"
+	// AdoptExistingAnnotation lets a Robot adopt an existing Deployment when
+	// "true", like spec.adoptExisting.
+	AdoptExistingAnnotation = "robot.llleon.io/adoptExisting"
+)
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -20,11 +46,410 @@ type Robot struct {
 type RobotSpec struct {
 	DeploymentName string `json:"deploymentName"`
 	Replicas       *int32 `json:"replicas"`
+	// Labels and Annotations are added to every object owned by the Robot,
+	// including its pods.
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Image is the container image run by the Robot. Defaults to nginx:latest.
+	Image string `json:"image,omitempty"`
+	// Resources of the Robot's container.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// ClassName is the RobotClass the Robot takes its defaults from.
+	ClassName string `json:"className,omitempty"`
+	// Analysis gates the rollout of a new pod template on metric queries.
+	Analysis *RobotAnalysis `json:"analysis,omitempty"`
+	// ConfigRefs are the ConfigMaps and Secrets the Robot reads its
+	// configuration from. Pods are restarted when their data changes.
+	ConfigRefs []ConfigRef `json:"configRefs,omitempty"`
+	// Config declares configuration files the controller keeps in a
+	// ConfigMap owned by the Robot.
+	Config *RobotConfig `json:"config,omitempty"`
+	// Secrets declares credentials generated once into a Secret owned by
+	// the Robot.
+	Secrets []GeneratedSecret `json:"secrets,omitempty"`
+	// ServiceAccount sets the ServiceAccount the Robot's pods run as.
+	ServiceAccount *RobotServiceAccount `json:"serviceAccount,omitempty"`
+	// Network isolates the Robot's pods with a NetworkPolicy owned by the
+	// Robot.
+	Network *RobotNetwork `json:"network,omitempty"`
+	// Storage provisions a PersistentVolumeClaim mounted into the Robot's
+	// pods.
+	Storage *RobotStorage `json:"storage,omitempty"`
+	// Sidecars run next to the Robot's container, after the default sidecars
+	// of the operator. A sidecar replaces the default sidecar of the same name.
+	Sidecars []corev1.Container `json:"sidecars,omitempty"`
+	// InitContainers run to completion before the Robot's containers start.
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+
+	NodeSelector      map[string]string   `json:"nodeSelector,omitempty"`
+	Affinity          *corev1.Affinity    `json:"affinity,omitempty"`
+	Tolerations       []corev1.Toleration `json:"tolerations,omitempty"`
+	PriorityClassName string              `json:"priorityClassName,omitempty"`
+	// SpreadAcrossZones spreads the Robot's pods evenly across zones.
+	SpreadAcrossZones bool `json:"spreadAcrossZones,omitempty"`
+	// SpreadAcrossNodes spreads the Robot's pods evenly across nodes, and
+	// keeps them on distinct nodes when possible.
+	SpreadAcrossNodes bool `json:"spreadAcrossNodes,omitempty"`
+
+	// Suspend scales the Robot's Deployment to zero without deleting it.
+	// Clearing it restores the replicas the Deployment ran before.
+	Suspend bool `json:"suspend,omitempty"`
+
+	// Schedules override Replicas and Suspend while they are in effect. The
+	// first schedule in effect wins.
+	Schedules []RobotSchedule `json:"schedules,omitempty"`
+
+	// Idle scales the Robot to zero once it has been quiet for a while.
+	Idle *RobotIdle `json:"idle,omitempty"`
+
+	// Paused stops the controller from changing the Robot's objects, which
+	// can then be edited by hand. Its status is still kept up to date.
+	Paused bool `json:"paused,omitempty"`
+
+	// AdoptExisting lets the Robot take over an existing Deployment named
+	// DeploymentName that no one controls, rather than refuse it. The
+	// Deployment keeps its selector, which must match the labels of the
+	// Robot's pods, spec.labels included, and select no other pods. It is
+	// then updated to match the Robot, pod template included.
+	AdoptExisting bool `json:"adoptExisting,omitempty"`
+
+	// TTLSecondsAfterCreation deletes the Robot, and the objects it owns,
+	// this long after it was created. A paused Robot is only deleted once
+	// it is resumed.
+	TTLSecondsAfterCreation *int32 `json:"ttlSecondsAfterCreation,omitempty"`
+	// ExpiresAt deletes the Robot, and the objects it owns, at this time.
+	// The earlier of TTLSecondsAfterCreation and ExpiresAt wins.
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// DependsOn lists the Robots which must have all their replicas
+	// available before the Robot's Deployment is created or scaled up.
+	DependsOn []RobotReference `json:"dependsOn,omitempty"`
+}
+
+// RobotReference refers to a Robot.
+type RobotReference struct {
+	// Namespace of the Robot. Defaults to the namespace of the referrer.
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// RobotIdle describes when a Robot is idle. Activity is read from the
+// lastActivity annotation of the Robot and, if set, from Query.
+type RobotIdle struct {
+	// QuietPeriodSeconds without activity after which the Robot is scaled
+	// to zero.
+	QuietPeriodSeconds int32 `json:"quietPeriodSeconds"`
+	// Query is a PromQL query returning the number of requests the Robot's
+	// pods served over the quiet period, e.g.
+	// sum(increase(http_requests_total{robot="name"}[10m])) or vector(0).
+	// Anything above zero is activity.
+	Query string `json:"query,omitempty"`
+}
+
+// RobotSchedule sets the replicas of a Robot for a while, starting at every
+// time its cron expression matches.
+type RobotSchedule struct {
+	Name string `json:"name"`
+	// Cron is a standard five field cron expression, e.g. "0 8 * * 1-5".
+	Cron string `json:"cron"`
+	// Duration is how long the schedule stays in effect once started.
+	Duration metav1.Duration `json:"duration"`
+	// TimeZone is the IANA name of the time zone Cron is evaluated in.
+	// Defaults to UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+	// Replicas the Robot runs while the schedule is in effect.
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Suspend suspends the Robot while the schedule is in effect.
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// RobotStorage describes the PersistentVolumeClaim of a Robot.
+type RobotStorage struct {
+	// Size of the claim. Growing it expands the claim online, if its
+	// StorageClass allows it. It can not shrink.
+	Size             resource.Quantity `json:"size"`
+	StorageClassName *string           `json:"storageClassName,omitempty"`
+	// AccessModes of the claim. Defaults to ReadWriteOnce.
+	AccessModes []corev1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+	// MountPath is where the volume is mounted. Defaults to /data.
+	MountPath string `json:"mountPath,omitempty"`
+	// ReclaimPolicy tells what happens to the claim when the Robot is
+	// deleted. Defaults to Retain.
+	ReclaimPolicy StorageReclaimPolicy `json:"reclaimPolicy,omitempty"`
+}
+
+// StorageReclaimPolicy tells what happens to the claim of a deleted Robot.
+type StorageReclaimPolicy string
+
+const (
+	// StorageReclaimRetain keeps the claim, which a Robot of the same name
+	// picks up again.
+	StorageReclaimRetain StorageReclaimPolicy = "Retain"
+	// StorageReclaimDelete deletes the claim along with the Robot.
+	StorageReclaimDelete StorageReclaimPolicy = "Delete"
+)
+
+// RobotNetwork lists the traffic allowed to and from the Robot's pods.
+type RobotNetwork struct {
+	// Ingress, when not empty, only allows matching traffic to the pods.
+	Ingress []NetworkRule `json:"ingress,omitempty"`
+	// Egress, when not empty, only allows matching traffic from the pods.
+	// Remember to allow DNS.
+	Egress []NetworkRule `json:"egress,omitempty"`
+}
+
+// NetworkRule allows traffic on Ports with the pods of Robots, the pods of
+// Namespaces and the CIDRs listed. A rule without peers matches every peer,
+// a rule without ports matches every port.
+type NetworkRule struct {
+	// Robots are names of Robots in the same namespace.
+	Robots []string `json:"robots,omitempty"`
+	// Namespaces are names of namespaces, matched through the
+	// kubernetes.io/metadata.name label. Kubernetes sets that label on every
+	// Namespace from 1.21 on; on older clusters it must be set by hand, or
+	// the namespace matches no pods.
+	Namespaces []string                         `json:"namespaces,omitempty"`
+	CIDRs      []string                         `json:"cidrs,omitempty"`
+	Ports      []networkingv1.NetworkPolicyPort `json:"ports,omitempty"`
+}
+
+// RobotServiceAccount describes the ServiceAccount of a Robot.
+type RobotServiceAccount struct {
+	// Create makes the controller own a ServiceAccount, and a Role and
+	// RoleBinding granting it Rules. Otherwise Name must be an existing
+	// ServiceAccount.
+	Create bool `json:"create,omitempty"`
+	// Name of the ServiceAccount. Defaults to the Robot's name when Create
+	// is set.
+	Name string `json:"name,omitempty"`
+	// Rules granted to the created ServiceAccount in the Robot's namespace.
+	// They must stay within the ceiling configured for the operator.
+	Rules []rbacv1.PolicyRule `json:"rules,omitempty"`
+}
+
+// GeneratedSecret describes a random value generated under Key in the Secret
+// owned by the Robot. The value is exposed to the Robot's container as the
+// environment variable Env, or as a file at MountPath, or both. When neither
+// is set it is exposed as an environment variable named after Key.
+type GeneratedSecret struct {
+	Key string `json:"key"`
+	// Length of the value, at most 1024. Defaults to 32.
+	Length int32 `json:"length,omitempty"`
+	// Charset the value is drawn from. Defaults to Alphanumeric.
+	Charset SecretCharset `json:"charset,omitempty"`
+	Env     string        `json:"env,omitempty"`
+	// MountPath is the path of the file holding the value.
+	MountPath string `json:"mountPath,omitempty"`
+}
+
+// SecretCharset is the set of characters a generated secret is drawn from.
+type SecretCharset string
+
+const (
+	SecretCharsetAlphanumeric SecretCharset = "Alphanumeric"
+	SecretCharsetNumeric      SecretCharset = "Numeric"
+	SecretCharsetHex          SecretCharset = "Hex"
+	// SecretCharsetSymbols is Alphanumeric plus printable ASCII symbols.
+	SecretCharsetSymbols SecretCharset = "Symbols"
+)
+
+// ConfigRef references a ConfigMap or a Secret in the Robot's namespace.
+// Exactly one of ConfigMapName and SecretName must be set.
+type ConfigRef struct {
+	ConfigMapName string `json:"configMapName,omitempty"`
+	SecretName    string `json:"secretName,omitempty"`
+	// MountPath mounts the data as files under this directory. When empty,
+	// the data is exposed as environment variables instead.
+	MountPath string `json:"mountPath,omitempty"`
+}
+
+// RobotConfig describes configuration files rendered into a ConfigMap owned
+// by the Robot and mounted into its pods.
+type RobotConfig struct {
+	// Files maps file names to their content.
+	Files map[string]string `json:"files"`
+	// Template renders every file as a Go template with the Robot's name,
+	// namespace, labels and annotations.
+	Template bool `json:"template,omitempty"`
+	// MountPath is where the files are mounted. Defaults to /etc/robot.
+	MountPath string `json:"mountPath,omitempty"`
+}
+
+// RobotAnalysis describes the metric checks evaluated while a new pod
+// template of the Robot is rolling out.
+type RobotAnalysis struct {
+	// IntervalSeconds is how often the queries are evaluated. Defaults to 30.
+	IntervalSeconds int32 `json:"intervalSeconds,omitempty"`
+	// FailureAction is applied to the Deployment when a query fails.
+	// Defaults to Pause.
+	FailureAction AnalysisFailureAction `json:"failureAction,omitempty"`
+	Queries       []AnalysisQuery       `json:"queries"`
+}
+
+// AnalysisFailureAction is the action taken on a failed rollout analysis.
+type AnalysisFailureAction string
+
+const (
+	// AnalysisFailurePause pauses the Deployment through spec.paused.
+	AnalysisFailurePause AnalysisFailureAction = "Pause"
+	// AnalysisFailureRollback reverts the Deployment to its previous template.
+	AnalysisFailureRollback AnalysisFailureAction = "Rollback"
+)
+
+// AnalysisQuery is a PromQL query and the threshold its result must satisfy.
+type AnalysisQuery struct {
+	Name string `json:"name"`
+	// Query must evaluate to a scalar or to a single-sample vector.
+	Query     string           `json:"query"`
+	Operator  AnalysisOperator `json:"operator"`
+	Threshold string           `json:"threshold"`
 }
 
+// AnalysisOperator compares a query result with its threshold.
+type AnalysisOperator string
+
+const (
+	AnalysisOperatorLessThan           AnalysisOperator = "LessThan"
+	AnalysisOperatorLessThanOrEqual    AnalysisOperator = "LessThanOrEqual"
+	AnalysisOperatorGreaterThan        AnalysisOperator = "GreaterThan"
+	AnalysisOperatorGreaterThanOrEqual AnalysisOperator = "GreaterThanOrEqual"
+)
+
 // RobotStatus is the status for a Robot resource.
 type RobotStatus struct {
-	AvailableReplicas int32 `json:"availableReplicas"`
+	// ObservedGeneration is the generation of the Robot the status is about.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	AvailableReplicas  int32 `json:"availableReplicas"`
+	// Analysis is the outcome of the rollout analysis of the current template.
+	Analysis *AnalysisStatus `json:"analysis,omitempty"`
+	// SuspendedReplicas are the replicas the Deployment ran before the Robot
+	// was suspended, restored on resume.
+	SuspendedReplicas *int32 `json:"suspendedReplicas,omitempty"`
+	// LastActivityTime is when the Robot was last seen active.
+	LastActivityTime *metav1.Time `json:"lastActivityTime,omitempty"`
+	// ExpirationTime is when the Robot is deleted, if it expires.
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
+	// ActiveSchedule is the name of the schedule in effect, if any.
+	ActiveSchedule string `json:"activeSchedule,omitempty"`
+	// NextScheduleTransition is when a schedule next starts or ends.
+	NextScheduleTransition *metav1.Time `json:"nextScheduleTransition,omitempty"`
+	// Pods sums up the pods of the Robot.
+	Pods RobotPodsStatus `json:"pods,omitempty"`
+	// Conditions are the latest observations of the Robot's state.
+	Conditions []RobotCondition `json:"conditions,omitempty"`
+}
+
+// RobotPodsStatus sums up the pods of a Robot.
+type RobotPodsStatus struct {
+	Ready   int32 `json:"ready"`
+	Pending int32 `json:"pending"`
+	// CrashLooping is the number of pods with a container restarting in a
+	// loop.
+	CrashLooping int32 `json:"crashLooping"`
+	// Restarts is the number of restarts of the containers of the pods.
+	Restarts int32 `json:"restarts"`
+	// LastFailure is the most recent termination of a container that failed,
+	// or container that cannot start.
+	LastFailure *RobotPodFailure `json:"lastFailure,omitempty"`
+	// Images are the images the containers of the pods run.
+	Images []RobotPodImage `json:"images,omitempty"`
+}
+
+// RobotPodFailure is a termination of a container that failed, or a container
+// that cannot start, such as one whose image cannot be pulled.
+type RobotPodFailure struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	// ExitCode is 0 for a container that cannot start.
+	ExitCode int32       `json:"exitCode"`
+	Reason   string      `json:"reason,omitempty"`
+	Message  string      `json:"message,omitempty"`
+	Time     metav1.Time `json:"time"`
+}
+
+// RobotPodImage is an image the containers of the pods of a Robot run.
+type RobotPodImage struct {
+	Image string `json:"image"`
+	// ImageID identifies the version of the image run, as the container
+	// runtime resolved it.
+	ImageID string `json:"imageID,omitempty"`
+	// Containers is the number of containers running the image.
+	Containers int32 `json:"containers"`
+}
+
+// RobotConditionType is a valid value for RobotCondition.Type.
+type RobotConditionType string
+
+const (
+	// RobotRBACRejected means the rules requested for the Robot's
+	// ServiceAccount exceed the operator's ceiling and were not applied.
+	RobotRBACRejected RobotConditionType = "RBACRejected"
+	// RobotSuspended means the Robot's Deployment is scaled to zero through
+	// spec.suspend.
+	RobotSuspended RobotConditionType = "Suspended"
+	// RobotPaused means the reconciliation of the Robot is paused through
+	// spec.paused or the paused annotation.
+	RobotPaused RobotConditionType = "Paused"
+	// RobotExpiring means the Robot expires shortly and will be deleted.
+	RobotExpiring RobotConditionType = "Expiring"
+	// RobotWaitingForDependencies means the Robot's Deployment is held back
+	// until the Robots it depends on are up, or forever if they form a
+	// cycle.
+	RobotWaitingForDependencies RobotConditionType = "WaitingForDependencies"
+	// RobotPolicyViolation means the Robot violates a RobotPolicy or a
+	// ClusterRobotPolicy and is not rendered.
+	RobotPolicyViolation RobotConditionType = "PolicyViolation"
+	// RobotRuleViolation means the Robot fails a rule of the operator config
+	// and is not reconciled.
+	RobotRuleViolation RobotConditionType = "RuleViolation"
+	// RobotQuotaExceeded means the Robot runs fewer replicas than it asks for
+	// because a RobotQuota of its namespace is used up.
+	RobotQuotaExceeded RobotConditionType = "QuotaExceeded"
+	// RobotIdleQueryUnavailable means spec.idle.query cannot be evaluated as
+	// no Prometheus endpoint is configured, so the Robot never turns idle.
+	RobotIdleQueryUnavailable RobotConditionType = "IdleQueryUnavailable"
+	// RobotConfigInvalid means the files of spec.config fail to render, so
+	// the Robot's ConfigMap and Deployment are left as they are.
+	RobotConfigInvalid RobotConditionType = "ConfigInvalid"
+)
+
+// RobotCondition describes the state of a Robot at a certain point.
+type RobotCondition struct {
+	Type               RobotConditionType     `json:"type"`
+	Status             corev1.ConditionStatus `json:"status"`
+	LastTransitionTime metav1.Time            `json:"lastTransitionTime,omitempty"`
+	Reason             string                 `json:"reason,omitempty"`
+	Message            string                 `json:"message,omitempty"`
+}
+
+// AnalysisPhase is the state of a rollout analysis or of one of its queries.
+type AnalysisPhase string
+
+const (
+	AnalysisPhaseRunning    AnalysisPhase = "Running"
+	AnalysisPhaseSuccessful AnalysisPhase = "Successful"
+	AnalysisPhaseFailed     AnalysisPhase = "Failed"
+	// AnalysisPhaseError means a query could not be evaluated. It does not
+	// fail the analysis on its own.
+	AnalysisPhaseError AnalysisPhase = "Error"
+)
+
+// AnalysisStatus records the rollout analysis of one pod template.
+type AnalysisStatus struct {
+	// TemplateHash identifies the pod template being analysed.
+	TemplateHash       string           `json:"templateHash"`
+	Phase              AnalysisPhase    `json:"phase"`
+	Message            string           `json:"message,omitempty"`
+	LastEvaluationTime *metav1.Time     `json:"lastEvaluationTime,omitempty"`
+	Results            []AnalysisResult `json:"results,omitempty"`
+}
+
+// AnalysisResult is the latest evaluation of one AnalysisQuery.
+type AnalysisResult struct {
+	Name    string        `json:"name"`
+	Phase   AnalysisPhase `json:"phase"`
+	Value   string        `json:"value,omitempty"`
+	Message string        `json:"message,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"io/ioutil"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Config is the operator-wide configuration, loaded from the file given with
+// the --config flag.
+type Config struct {
+	// RBACCeiling bounds the rules a Robot may grant to its ServiceAccount.
+	// When unset, no rule is allowed.
+	RBACCeiling []rbacv1.PolicyRule `json:"rbacCeiling,omitempty"`
+	// DefaultSidecars are added to the pods of every Robot which does not
+	// opt out of them.
+	DefaultSidecars []corev1.Container `json:"defaultSidecars,omitempty"`
+	// Rules are checked against every Robot, at admission and when it is
+	// reconciled.
+	Rules []Rule `json:"rules,omitempty"`
+}
+
+// Load reads the Config in the YAML file at path. An empty path yields the
+// default Config.
+func Load(path string) (*Config, error) {
+	cfg := &Config{}
+	if path == "" {
+		return cfg, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, err
+	}
+	if err := compileRules(cfg.Rules); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
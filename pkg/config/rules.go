@@ -0,0 +1,87 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+)
+
+// Rule is a CEL expression every Robot must satisfy.
+type Rule struct {
+	// Name identifies the rule in denials and metrics.
+	Name string `json:"name"`
+	// Expression is evaluated with the Robot, as it is stored, bound to
+	// robot, e.g. "robot.spec.replicas <= 10". It must yield true for the
+	// Robot to be allowed.
+	Expression string `json:"expression"`
+	// Message explains a denial, defaulting to the expression.
+	Message string `json:"message,omitempty"`
+
+	program cel.Program
+}
+
+// compileRules compiles the expressions of rules, refusing those which do not
+// yield a bool.
+func compileRules(rules []Rule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	env, err := cel.NewEnv(cel.Declarations(decls.NewVar("robot", decls.Dyn)))
+	if err != nil {
+		return err
+	}
+
+	names := make(map[string]bool, len(rules))
+	for i := range rules {
+		rule := &rules[i]
+		if rule.Name == "" {
+			return fmt.Errorf("rule %d has no name", i)
+		}
+		if names[rule.Name] {
+			return fmt.Errorf("rule %q is defined twice", rule.Name)
+		}
+		names[rule.Name] = true
+
+		ast, issues := env.Compile(rule.Expression)
+		if issues != nil && issues.Err() != nil {
+			return fmt.Errorf("rule %q: %s", rule.Name, issues.Err().Error())
+		}
+		if result := ast.ResultType(); result.GetPrimitive() != exprpb.Type_BOOL && result.GetDyn() == nil {
+			return fmt.Errorf("rule %q: expression must yield a bool", rule.Name)
+		}
+
+		if rule.program, err = env.Program(ast); err != nil {
+			return fmt.Errorf("rule %q: %s", rule.Name, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// Allows evaluates the rule against robot, the unstructured content of a
+// Robot.
+func (r *Rule) Allows(robot map[string]interface{}) (bool, error) {
+	out, _, err := r.program.Eval(map[string]interface{}{"robot": robot})
+	if err != nil {
+		return false, err
+	}
+
+	allowed, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression yielded %v, not a bool", out.Value())
+	}
+
+	return allowed, nil
+}
+
+// DenialMessage returns the message explaining a denial by the rule.
+func (r *Rule) DenialMessage() string {
+	if r.Message != "" {
+		return r.Message
+	}
+
+	return fmt.Sprintf("failed rule %s", r.Expression)
+}
@@ -0,0 +1,236 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+const (
+	// activationPath is followed by the namespace and name of the Robot to
+	// activate.
+	activationPath = "/activate/"
+
+	defaultActivationTimeout = 2 * time.Minute
+	maxActivationTimeout     = 5 * time.Minute
+	activationPollInterval   = time.Second
+
+	// activityResolution is how old the lastActivity annotation of a Robot
+	// must be before an activation updates it again.
+	activityResolution = 10 * time.Second
+)
+
+// ServeActivation serves the activation endpoint on address until stopCh is
+// closed. A POST to /activate/<namespace>/<name> marks the Robot as active,
+// which wakes it up if it is idle, and answers once its Deployment has an
+// available replica. The wait is bounded by the timeout query parameter, a
+// duration defaulting to two minutes and capped at five. Suspended Robots
+// cannot be woken and are answered with a conflict right away. In dry-run
+// mode activations are answered with 202 Accepted without waiting.
+//
+// Activations carry a bearer token, which is checked with a TokenReview, and
+// are only allowed to users that may patch the Robot, as checked with a
+// SubjectAccessReview.
+func (c *Controller) ServeActivation(address string, stopCh <-chan struct{}) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(activationPath, c.activate)
+	server := &http.Server{Addr: address, Handler: mux}
+
+	go func() {
+		<-stopCh
+		server.Shutdown(context.Background())
+	}()
+
+	klog.Infof("Serving activation endpoint on %s", address)
+	if err := server.ListenAndServe(); err != http.ErrServerClosed {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Controller) activate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !c.robotsSynced() || !c.deploymentsSynced() {
+		http.Error(w, "caches not synced yet", http.StatusServiceUnavailable)
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, activationPath), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		http.Error(w, "expected "+activationPath+"<namespace>/<name>", http.StatusNotFound)
+		return
+	}
+	namespace, name := parts[0], parts[1]
+
+	if status, msg := c.authorizeActivation(r, namespace, name); status != http.StatusOK {
+		http.Error(w, msg, status)
+		return
+	}
+
+	timeout := defaultActivationTimeout
+	if value := r.URL.Query().Get("timeout"); value != "" {
+		var err error
+		if timeout, err = time.ParseDuration(value); err != nil {
+			http.Error(w, fmt.Sprintf("invalid timeout: %s", err.Error()), http.StatusBadRequest)
+			return
+		}
+		if timeout > maxActivationTimeout {
+			timeout = maxActivationTimeout
+		}
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	robot, err := c.robotsLister.Robots(namespace).Get(name)
+	if errors.IsNotFound(err) {
+		http.Error(w, fmt.Sprintf("Robot %s/%s not found", namespace, name), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if suspended(robot) {
+		http.Error(w, fmt.Sprintf("Robot %s/%s is suspended", namespace, name), http.StatusConflict)
+		return
+	}
+
+	if err := c.recordActivity(ctx, robot); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// in dry-run mode the activity is never recorded, so the Robot never
+	// wakes up: answer right away that it would have been activated
+	if c.dryRun {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	klog.V(4).Infof("Activating Robot %s/%s", namespace, name)
+	err = wait.PollImmediateUntil(activationPollInterval, func() (bool, error) {
+		deployment, err := c.deploymentsLister.Deployments(namespace).Get(robot.Spec.DeploymentName)
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+
+		return deployment.Status.AvailableReplicas > 0, nil
+	}, ctx.Done())
+	if err == wait.ErrWaitTimeout {
+		http.Error(w, fmt.Sprintf("Robot %s/%s not ready after %s", namespace, name, timeout), http.StatusGatewayTimeout)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// authorizeActivation checks that the bearer token of r belongs to a user
+// allowed to patch the Robot name in namespace, which activating it amounts
+// to. It returns the HTTP status to answer with when not, and why.
+func (c *Controller) authorizeActivation(r *http.Request, namespace, name string) (int, string) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" || token == r.Header.Get("Authorization") {
+		return http.StatusUnauthorized, "expected a bearer token"
+	}
+
+	tokenReview, err := c.kubeClientset.AuthenticationV1().TokenReviews().Create(r.Context(), &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return http.StatusInternalServerError, err.Error()
+	}
+	if !tokenReview.Status.Authenticated {
+		return http.StatusUnauthorized, "invalid bearer token"
+	}
+
+	user := tokenReview.Status.User
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	accessReview, err := c.kubeClientset.AuthorizationV1().SubjectAccessReviews().Create(r.Context(), &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "patch",
+				Group:     robotv1.SchemeGroupVersion.Group,
+				Resource:  "robots",
+				Name:      name,
+			},
+			User:   user.Username,
+			Groups: user.Groups,
+			Extra:  extra,
+			UID:    user.UID,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return http.StatusInternalServerError, err.Error()
+	}
+	if !accessReview.Status.Allowed {
+		return http.StatusForbidden, fmt.Sprintf("%s may not activate Robot %s/%s", user.Username, namespace, name)
+	}
+
+	return http.StatusOK, ""
+}
+
+// suspended reports whether robot is scaled to zero through spec.suspend or
+// the schedule in effect, which activity does not wake it from.
+func suspended(robot *robotv1.Robot) bool {
+	for _, schedule := range robot.Spec.Schedules {
+		if schedule.Name == robot.Status.ActiveSchedule {
+			return schedule.Suspend
+		}
+	}
+
+	return robot.Spec.Suspend
+}
+
+// recordActivity sets the lastActivity annotation of robot to the current
+// time, unless it was set recently.
+func (c *Controller) recordActivity(ctx context.Context, robot *robotv1.Robot) error {
+	now := time.Now()
+	if value, ok := robot.Annotations[robotv1.LastActivityAnnotation]; ok {
+		if t, err := time.Parse(time.RFC3339, value); err == nil && now.Sub(t) < activityResolution {
+			return nil
+		}
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				robotv1.LastActivityAnnotation: now.UTC().Format(time.RFC3339),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.robotClientset.RobotV1().Robots(robot.Namespace).Patch(ctx, robot.Name, types.MergePatchType, patch, c.patchOptions(robot, "Robot", robot.Name))
+
+	return err
+}
@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+const (
+	Adopted                 = "Adopted"
+	ErrAdoption             = "ErrAdoption"
+	TemplateReplaced        = "TemplateReplaced"
+	MessageAdopted          = "Adopted Deployment %q"
+	MessageAdoption         = "Refusing to adopt Deployment %q: %s"
+	MessageTemplateReplaced = "Adopting Deployment %q replaces the images %v of its pods with %v"
+)
+
+// adoptExisting reports whether robot may take over a Deployment it does not
+// control.
+func adoptExisting(robot *robotv1.Robot) bool {
+	return robot.Spec.AdoptExisting || robot.Annotations[robotv1.AdoptExistingAnnotation] == "true"
+}
+
+// adoptDeployment makes robot the controller of deployment, after which the
+// Deployment is brought in line with robot like any other. Deployments that
+// robot can not safely manage are refused, and a warning is emitted when the
+// pods of deployment are to run other images. configHash is the hash of the
+// configuration of robot.
+func (c *Controller) adoptDeployment(robot *robotv1.Robot, deployment *appsv1.Deployment, configHash string) (*appsv1.Deployment, error) {
+	reason, err := c.adoptionRefused(robot, deployment)
+	if err != nil {
+		return nil, err
+	}
+	if reason != "" {
+		msg := fmt.Sprintf(MessageAdoption, deployment.Name, reason)
+		c.recorder.Event(robot, corev1.EventTypeWarning, ErrAdoption, msg)
+		return nil, fmt.Errorf(msg)
+	}
+
+	deploymentCopy := deployment.DeepCopy()
+	deploymentCopy.OwnerReferences = append(deploymentCopy.OwnerReferences,
+		*metav1.NewControllerRef(robot, robotv1.SchemeGroupVersion.WithKind("Robot")))
+	adopted, err := c.kubeClientset.AppsV1().Deployments(robot.Namespace).Update(context.TODO(), deploymentCopy, c.updateOptions(robot, "Deployment", deployment.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	c.recorder.Eventf(robot, corev1.EventTypeNormal, Adopted, MessageAdopted, deployment.Name)
+
+	// the pod template of the Deployment is replaced by the one of robot,
+	// which may not run what the Deployment was made to run
+	current := containerImages(&deployment.Spec.Template.Spec)
+	desired := containerImages(&newDeployment(robot, configHash, c.config.DefaultSidecars).Spec.Template.Spec)
+	if !equality.Semantic.DeepEqual(current, desired) {
+		c.recorder.Eventf(robot, corev1.EventTypeWarning, TemplateReplaced, MessageTemplateReplaced, deployment.Name, current, desired)
+	}
+
+	return adopted, nil
+}
+
+// adoptionRefused returns why robot can not adopt deployment, "" if it can.
+// The selector of a Deployment is immutable, so robot must be able to keep it:
+// it must select the pods of robot and nothing else.
+func (c *Controller) adoptionRefused(robot *robotv1.Robot, deployment *appsv1.Deployment) (string, error) {
+	if owner := metav1.GetControllerOf(deployment); owner != nil {
+		return fmt.Sprintf("it is controlled by %s %q", owner.Kind, owner.Name), nil
+	}
+	if deployment.DeletionTimestamp != nil {
+		return "it is being deleted", nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return fmt.Sprintf("its selector is invalid: %s", err.Error()), nil
+	}
+	if selector.Empty() {
+		return "its selector selects every pod", nil
+	}
+
+	// the selector is kept, so it must match the labels robot gives its pods
+	// by itself
+	if !selector.Matches(labels.Set(objectLabels(robot))) {
+		return fmt.Sprintf("its selector %q does not match the labels of the Robot's pods, add them to its spec.labels", selector.String()), nil
+	}
+
+	// nor may it select pods that are not the Deployment's
+	deployments, err := c.deploymentsLister.Deployments(robot.Namespace).List(labels.Everything())
+	if err != nil {
+		return "", err
+	}
+	for _, other := range deployments {
+		if other.Name != deployment.Name && selector.Matches(labels.Set(other.Spec.Template.Labels)) {
+			return fmt.Sprintf("its selector %q also selects the pods of Deployment %q", selector.String(), other.Name), nil
+		}
+	}
+
+	replicaSets, err := c.replicaSetsLister.ReplicaSets(robot.Namespace).List(labels.Everything())
+	if err != nil {
+		return "", err
+	}
+	owned := make(map[string]bool)
+	for _, replicaSet := range replicaSets {
+		if metav1.IsControlledBy(replicaSet, deployment) {
+			owned[replicaSet.Name] = true
+			continue
+		}
+		if selector.Matches(labels.Set(replicaSet.Spec.Template.Labels)) {
+			return fmt.Sprintf("its selector %q also selects the pods of ReplicaSet %q", selector.String(), replicaSet.Name), nil
+		}
+	}
+
+	// the pod informer only sees the pods of Robots, so the pods are listed
+	// from the API
+	pods, err := c.kubeClientset.CoreV1().Pods(robot.Namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return "", err
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if owner := metav1.GetControllerOf(pod); owner != nil && owner.Kind == "ReplicaSet" && owned[owner.Name] {
+			continue
+		}
+		return fmt.Sprintf("its selector %q also selects pod %q", selector.String(), pod.Name), nil
+	}
+
+	return "", nil
+}
+
+// containerImages returns the images of the containers of spec, in order.
+func containerImages(spec *corev1.PodSpec) []string {
+	images := make([]string, 0, len(spec.Containers))
+	for _, container := range spec.Containers {
+		images = append(images, container.Image)
+	}
+
+	return images
+}
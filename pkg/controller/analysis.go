@@ -0,0 +1,258 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+const (
+	AnalysisFailed             = "AnalysisFailed"
+	AnalysisUnavailable        = "AnalysisUnavailable"
+	RolloutPaused              = "RolloutPaused"
+	RolloutReverted            = "RolloutReverted"
+	MessageAnalysisFailed      = "Rollout analysis of template %s failed: %s"
+	MessageAnalysisUnavailable = "No Prometheus endpoint configured, cannot analyse rollout"
+	MessageRolloutPaused       = "Deployment %q paused after failed rollout analysis"
+	MessageRolloutReverted     = "Deployment %q reverted to its previous template after failed rollout analysis"
+
+	revisionAnnotation = "deployment.kubernetes.io/revision"
+
+	defaultAnalysisInterval = 30 * time.Second
+	queryTimeout            = 10 * time.Second
+)
+
+// analysisInterval returns how often the queries of analysis are evaluated.
+func analysisInterval(analysis *robotv1.RobotAnalysis) time.Duration {
+	if analysis == nil || analysis.IntervalSeconds <= 0 {
+		return defaultAnalysisInterval
+	}
+
+	return time.Duration(analysis.IntervalSeconds) * time.Second
+}
+
+// analysisFailed reports whether the template with templateHash failed the
+// rollout analysis of robot.
+func analysisFailed(robot *robotv1.Robot, templateHash string) bool {
+	status := robot.Status.Analysis
+
+	return robot.Spec.Analysis != nil && status != nil &&
+		status.TemplateHash == templateHash && status.Phase == robotv1.AnalysisPhaseFailed
+}
+
+// analyzeRollout evaluates the rollout analysis of robot against the template
+// currently applied to deployment, pausing or reverting the Deployment when it
+// fails, and returns the resulting analysis status.
+func (c *Controller) analyzeRollout(robot *robotv1.Robot, deployment *appsv1.Deployment) (*robotv1.AnalysisStatus, error) {
+	spec := robot.Spec.Analysis
+	if spec == nil {
+		return nil, nil
+	}
+
+	templateHash := deployment.Annotations[templateHashAnnotation]
+	status := robot.Status.Analysis.DeepCopy()
+	if status == nil || status.TemplateHash != templateHash {
+		status = &robotv1.AnalysisStatus{
+			TemplateHash: templateHash,
+			Phase:        robotv1.AnalysisPhaseRunning,
+		}
+	}
+
+	// the outcome for this template is already known
+	if status.Phase != robotv1.AnalysisPhaseRunning {
+		return status, nil
+	}
+
+	// warn once, when the message appears
+	if c.prometheus == nil {
+		if status.Message != MessageAnalysisUnavailable {
+			c.recorder.Event(robot, corev1.EventTypeWarning, AnalysisUnavailable, MessageAnalysisUnavailable)
+		}
+		status.Message = MessageAnalysisUnavailable
+		return status, nil
+	}
+
+	complete := rolloutComplete(deployment)
+	due := status.LastEvaluationTime == nil || time.Since(status.LastEvaluationTime.Time) >= analysisInterval(spec)
+	if !due {
+		if complete && allSuccessful(status.Results) {
+			status.Phase = robotv1.AnalysisPhaseSuccessful
+		}
+		return status, nil
+	}
+
+	var failed []string
+	status.Results = make([]robotv1.AnalysisResult, 0, len(spec.Queries))
+	for _, query := range spec.Queries {
+		result := c.evaluateQuery(query)
+		if result.Phase == robotv1.AnalysisPhaseFailed {
+			failed = append(failed, query.Name)
+		}
+		status.Results = append(status.Results, result)
+	}
+	now := metav1.Now()
+	status.LastEvaluationTime = &now
+	status.Message = ""
+
+	switch {
+	case len(failed) > 0:
+		status.Phase = robotv1.AnalysisPhaseFailed
+		status.Message = fmt.Sprintf("failed queries: %s", strings.Join(failed, ", "))
+		c.recorder.Eventf(robot, corev1.EventTypeWarning, AnalysisFailed, MessageAnalysisFailed, templateHash, status.Message)
+		if err := c.failRollout(robot, deployment, spec.FailureAction); err != nil {
+			return nil, err
+		}
+	case complete && allSuccessful(status.Results):
+		status.Phase = robotv1.AnalysisPhaseSuccessful
+	}
+
+	return status, nil
+}
+
+// evaluateQuery runs query and checks its value against the threshold.
+func (c *Controller) evaluateQuery(query robotv1.AnalysisQuery) robotv1.AnalysisResult {
+	result := robotv1.AnalysisResult{Name: query.Name, Phase: robotv1.AnalysisPhaseError}
+
+	threshold, err := strconv.ParseFloat(query.Threshold, 64)
+	if err != nil {
+		result.Message = fmt.Sprintf("invalid threshold %q", query.Threshold)
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), queryTimeout)
+	defer cancel()
+	value, err := c.prometheus.Query(ctx, query.Query)
+	if err != nil {
+		result.Message = err.Error()
+		return result
+	}
+	result.Value = strconv.FormatFloat(value, 'f', -1, 64)
+
+	var ok bool
+	switch query.Operator {
+	case robotv1.AnalysisOperatorLessThan:
+		ok = value < threshold
+	case robotv1.AnalysisOperatorLessThanOrEqual:
+		ok = value <= threshold
+	case robotv1.AnalysisOperatorGreaterThan:
+		ok = value > threshold
+	case robotv1.AnalysisOperatorGreaterThanOrEqual:
+		ok = value >= threshold
+	default:
+		result.Message = fmt.Sprintf("unknown operator %q", query.Operator)
+		return result
+	}
+
+	if ok {
+		result.Phase = robotv1.AnalysisPhaseSuccessful
+	} else {
+		result.Phase = robotv1.AnalysisPhaseFailed
+		result.Message = fmt.Sprintf("%s is not %s %s", result.Value, query.Operator, query.Threshold)
+	}
+
+	return result
+}
+
+// failRollout applies action to deployment. Rolling back falls back to
+// pausing when the Deployment has no previous revision.
+func (c *Controller) failRollout(robot *robotv1.Robot, deployment *appsv1.Deployment, action robotv1.AnalysisFailureAction) error {
+	deploymentCopy := deployment.DeepCopy()
+
+	if action == robotv1.AnalysisFailureRollback {
+		template, err := c.previousTemplate(deployment)
+		if err != nil {
+			return err
+		}
+
+		if template != nil {
+			deploymentCopy.Spec.Template = *template
+			_, err = c.kubeClientset.AppsV1().Deployments(deployment.Namespace).Update(context.TODO(), deploymentCopy, c.updateOptions(robot, "Deployment", deployment.Name))
+			if err == nil {
+				c.recorder.Eventf(robot, corev1.EventTypeWarning, RolloutReverted, MessageRolloutReverted, deployment.Name)
+			}
+			return err
+		}
+	}
+
+	deploymentCopy.Spec.Paused = true
+	_, err := c.kubeClientset.AppsV1().Deployments(deployment.Namespace).Update(context.TODO(), deploymentCopy, c.updateOptions(robot, "Deployment", deployment.Name))
+	if err == nil {
+		c.recorder.Eventf(robot, corev1.EventTypeWarning, RolloutPaused, MessageRolloutPaused, deployment.Name)
+	}
+
+	return err
+}
+
+// previousTemplate returns the pod template of the newest ReplicaSet of
+// deployment older than its current revision, nil if there is none.
+func (c *Controller) previousTemplate(deployment *appsv1.Deployment) (*corev1.PodTemplateSpec, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	replicaSets, err := c.replicaSetsLister.ReplicaSets(deployment.Namespace).List(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	current, _ := strconv.ParseInt(deployment.Annotations[revisionAnnotation], 10, 64)
+
+	var (
+		previous         *appsv1.ReplicaSet
+		previousRevision int64
+	)
+	for _, rs := range replicaSets {
+		if !metav1.IsControlledBy(rs, deployment) {
+			continue
+		}
+		revision, err := strconv.ParseInt(rs.Annotations[revisionAnnotation], 10, 64)
+		if err != nil || revision >= current {
+			continue
+		}
+		if revision > previousRevision {
+			previous, previousRevision = rs, revision
+		}
+	}
+
+	if previous == nil {
+		return nil, nil
+	}
+
+	template := previous.Spec.Template.DeepCopy()
+	delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+
+	return template, nil
+}
+
+// rolloutComplete reports whether every replica of deployment runs its
+// current template and is available.
+func rolloutComplete(deployment *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.Replicas == replicas &&
+		deployment.Status.AvailableReplicas == replicas
+}
+
+func allSuccessful(results []robotv1.AnalysisResult) bool {
+	for _, result := range results {
+		if result.Phase != robotv1.AnalysisPhaseSuccessful {
+			return false
+		}
+	}
+
+	return true
+}
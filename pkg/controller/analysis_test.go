@@ -0,0 +1,167 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+// stubPrometheus answers queries with fixed values.
+type stubPrometheus map[string]float64
+
+func (s stubPrometheus) Query(ctx context.Context, query string) (float64, error) {
+	value, ok := s[query]
+	if !ok {
+		return 0, fmt.Errorf("unknown query %q", query)
+	}
+
+	return value, nil
+}
+
+func newAnalysisRobot(queries ...robotv1.AnalysisQuery) *robotv1.Robot {
+	return &robotv1.Robot{
+		ObjectMeta: metav1.ObjectMeta{Name: "robot", Namespace: metav1.NamespaceDefault},
+		Spec: robotv1.RobotSpec{
+			DeploymentName: "robot",
+			Analysis: &robotv1.RobotAnalysis{
+				Queries:       queries,
+				FailureAction: robotv1.AnalysisFailurePause,
+			},
+		},
+	}
+}
+
+// newRolledOutDeployment returns a Deployment whose rollout of the template
+// with hash is complete.
+func newRolledOutDeployment(hash string) *appsv1.Deployment {
+	one := int32(1)
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "robot",
+			Namespace:   metav1.NamespaceDefault,
+			Annotations: map[string]string{templateHashAnnotation: hash},
+		},
+		Spec: appsv1.DeploymentSpec{Replicas: &one},
+		Status: appsv1.DeploymentStatus{
+			Replicas:          1,
+			UpdatedReplicas:   1,
+			AvailableReplicas: 1,
+		},
+	}
+}
+
+func TestAnalyzeRollout(t *testing.T) {
+	errorRate := robotv1.AnalysisQuery{Name: "error-rate", Query: "errors", Operator: robotv1.AnalysisOperatorLessThan, Threshold: "0.05"}
+
+	tests := []struct {
+		name       string
+		query      robotv1.AnalysisQuery
+		value      float64
+		wantPhase  robotv1.AnalysisPhase
+		wantResult robotv1.AnalysisPhase
+		wantPaused bool
+	}{
+		{
+			name:       "pass",
+			query:      errorRate,
+			value:      0.01,
+			wantPhase:  robotv1.AnalysisPhaseSuccessful,
+			wantResult: robotv1.AnalysisPhaseSuccessful,
+		},
+		{
+			name:       "fail",
+			query:      errorRate,
+			value:      0.5,
+			wantPhase:  robotv1.AnalysisPhaseFailed,
+			wantResult: robotv1.AnalysisPhaseFailed,
+			wantPaused: true,
+		},
+		{
+			name:       "at threshold fails a strict comparison",
+			query:      errorRate,
+			value:      0.05,
+			wantPhase:  robotv1.AnalysisPhaseFailed,
+			wantResult: robotv1.AnalysisPhaseFailed,
+			wantPaused: true,
+		},
+		{
+			name:       "at threshold passes an inclusive comparison",
+			query:      robotv1.AnalysisQuery{Name: "error-rate", Query: "errors", Operator: robotv1.AnalysisOperatorLessThanOrEqual, Threshold: "0.05"},
+			value:      0.05,
+			wantPhase:  robotv1.AnalysisPhaseSuccessful,
+			wantResult: robotv1.AnalysisPhaseSuccessful,
+		},
+		{
+			name:       "invalid threshold",
+			query:      robotv1.AnalysisQuery{Name: "error-rate", Query: "errors", Operator: robotv1.AnalysisOperatorLessThan, Threshold: "low"},
+			value:      0.01,
+			wantPhase:  robotv1.AnalysisPhaseRunning,
+			wantResult: robotv1.AnalysisPhaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			robot := newAnalysisRobot(tt.query)
+			deployment := newRolledOutDeployment("abc")
+			kubeClient := fake.NewSimpleClientset(deployment)
+			c := &Controller{
+				kubeClientset: kubeClient,
+				prometheus:    stubPrometheus{tt.query.Query: tt.value},
+				recorder:      record.NewFakeRecorder(10),
+			}
+
+			status, err := c.analyzeRollout(robot, deployment)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if status.Phase != tt.wantPhase {
+				t.Errorf("got phase %q, want %q", status.Phase, tt.wantPhase)
+			}
+			if status.TemplateHash != "abc" {
+				t.Errorf("got template hash %q, want %q", status.TemplateHash, "abc")
+			}
+			if len(status.Results) != 1 || status.Results[0].Phase != tt.wantResult {
+				t.Errorf("got results %+v, want one in phase %q", status.Results, tt.wantResult)
+			}
+
+			updated, err := kubeClient.AppsV1().Deployments(deployment.Namespace).Get(context.TODO(), deployment.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if updated.Spec.Paused != tt.wantPaused {
+				t.Errorf("got paused %v, want %v", updated.Spec.Paused, tt.wantPaused)
+			}
+		})
+	}
+}
+
+func TestAnalyzeRolloutUnavailable(t *testing.T) {
+	robot := newAnalysisRobot(robotv1.AnalysisQuery{Name: "error-rate", Query: "errors", Operator: robotv1.AnalysisOperatorLessThan, Threshold: "0.05"})
+	deployment := newRolledOutDeployment("abc")
+	recorder := record.NewFakeRecorder(10)
+	c := &Controller{recorder: recorder}
+
+	for i := 0; i < 3; i++ {
+		status, err := c.analyzeRollout(robot, deployment)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		if status.Phase != robotv1.AnalysisPhaseRunning || status.Message != MessageAnalysisUnavailable {
+			t.Fatalf("got phase %q and message %q", status.Phase, status.Message)
+		}
+		robot.Status.Analysis = status
+	}
+
+	if len(recorder.Events) != 1 {
+		t.Errorf("got %d events, want 1", len(recorder.Events))
+	}
+}
@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+const (
+	// classIndex indexes Robots by the name of their RobotClass.
+	classIndex = "class"
+
+	ErrUnknownClass     = "ErrUnknownClass"
+	MessageUnknownClass = "RobotClass %q does not exist"
+)
+
+// indexRobotClasses is a cache.IndexFunc returning the name of the RobotClass
+// of a Robot.
+func indexRobotClasses(obj interface{}) ([]string, error) {
+	robot, ok := obj.(*robotv1.Robot)
+	if !ok || robot.Spec.ClassName == "" {
+		return nil, nil
+	}
+
+	return []string{robot.Spec.ClassName}, nil
+}
+
+// handleClass enqueues every Robot of the RobotClass obj.
+func (c *Controller) handleClass(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	class, ok := obj.(*robotv1.RobotClass)
+	if !ok {
+		utilruntime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+		return
+	}
+
+	robots, err := c.robotsIndexer.ByIndex(classIndex, class.Name)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+
+	for _, robot := range robots {
+		klog.V(4).Infof("RobotClass '%s' changed, enqueueing Robot", class.Name)
+		c.enqueueRobot(robot)
+	}
+}
+
+// applyClass returns a copy of robot with the defaults of its RobotClass
+// merged in, robot itself if it has no class, or nil if its class does not
+// exist.
+func (c *Controller) applyClass(robot *robotv1.Robot) (*robotv1.Robot, error) {
+	if robot.Spec.ClassName == "" {
+		return robot, nil
+	}
+
+	class, err := c.robotClassesLister.Get(robot.Spec.ClassName)
+	if errors.IsNotFound(err) {
+		c.recorder.Eventf(robot, corev1.EventTypeWarning, ErrUnknownClass, MessageUnknownClass, robot.Spec.ClassName)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeClass(robot, class), nil
+}
+
+// mergeClass returns a copy of robot with the defaults of class merged in.
+func mergeClass(robot *robotv1.Robot, class *robotv1.RobotClass) *robotv1.Robot {
+	robot = robot.DeepCopy()
+	spec, defaults := &robot.Spec, &class.Spec
+
+	if spec.Image == "" {
+		spec.Image = defaults.Image
+	}
+	if spec.Resources == nil {
+		spec.Resources = defaults.Resources.DeepCopy()
+	}
+	spec.Labels = mergeDefaults(spec.Labels, defaults.Labels)
+	spec.Annotations = mergeDefaults(spec.Annotations, defaults.Annotations)
+	spec.NodeSelector = mergeDefaults(spec.NodeSelector, defaults.NodeSelector)
+	if spec.Affinity == nil {
+		spec.Affinity = defaults.Affinity.DeepCopy()
+	}
+	if spec.Tolerations == nil {
+		spec.Tolerations = defaults.Tolerations
+	}
+	if spec.PriorityClassName == "" {
+		spec.PriorityClassName = defaults.PriorityClassName
+	}
+	spec.SpreadAcrossZones = spec.SpreadAcrossZones || defaults.SpreadAcrossZones
+	spec.SpreadAcrossNodes = spec.SpreadAcrossNodes || defaults.SpreadAcrossNodes
+
+	if registry := defaults.ImageRegistry; registry != "" {
+		if spec.Image == "" {
+			spec.Image = defaultImage
+		}
+		spec.Image = withRegistry(registry, spec.Image)
+		for i := range spec.Sidecars {
+			spec.Sidecars[i].Image = withRegistry(registry, spec.Sidecars[i].Image)
+		}
+		for i := range spec.InitContainers {
+			spec.InitContainers[i].Image = withRegistry(registry, spec.InitContainers[i].Image)
+		}
+	}
+
+	return robot
+}
+
+// mergeDefaults returns m with the entries of defaults it lacks.
+func mergeDefaults(m, defaults map[string]string) map[string]string {
+	if len(defaults) == 0 {
+		return m
+	}
+
+	merged := make(map[string]string, len(m)+len(defaults))
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	for k, v := range m {
+		merged[k] = v
+	}
+
+	return merged
+}
+
+// withRegistry prefixes image with registry unless it names a registry
+// already.
+func withRegistry(registry, image string) string {
+	if image == "" || imageRegistry(image) != "" {
+		return image
+	}
+
+	return strings.TrimSuffix(registry, "/") + "/" + image
+}
+
+// imageRegistry returns the registry image names, or "" if it names none.
+// The first component of image names a registry when it holds a "." or a ":"
+// or is localhost.
+func imageRegistry(image string) string {
+	if i := strings.Index(image, "/"); i >= 0 {
+		first := image[:i]
+		if strings.ContainsAny(first, ".:") || first == "localhost" {
+			return first
+		}
+	}
+
+	return ""
+}
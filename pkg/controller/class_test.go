@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+func TestWithRegistry(t *testing.T) {
+	tests := []struct {
+		image string
+		want  string
+	}{
+		{image: "nginx", want: "registry.example.com/nginx"},
+		{image: "library/nginx:1.19", want: "registry.example.com/library/nginx:1.19"},
+		{image: "quay.io/robots/robot", want: "quay.io/robots/robot"},
+		{image: "registry:5000/robot", want: "registry:5000/robot"},
+		{image: "localhost/robot", want: "localhost/robot"},
+		{image: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			if got := withRegistry("registry.example.com/", tt.image); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeClass(t *testing.T) {
+	class := &robotv1.RobotClass{
+		Spec: robotv1.RobotClassSpec{
+			Image:             "robot:stable",
+			Labels:            map[string]string{"team": "robots", "tier": "backend"},
+			NodeSelector:      map[string]string{"pool": "robots"},
+			Tolerations:       []corev1.Toleration{{Key: "robots", Effect: corev1.TaintEffectNoSchedule}},
+			PriorityClassName: "low",
+			SpreadAcrossZones: true,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		spec     robotv1.RobotSpec
+		registry string
+		want     robotv1.RobotSpec
+	}{
+		{
+			name: "defaults fill the Robot",
+			want: robotv1.RobotSpec{
+				Image:             "robot:stable",
+				Labels:            map[string]string{"team": "robots", "tier": "backend"},
+				NodeSelector:      map[string]string{"pool": "robots"},
+				Tolerations:       []corev1.Toleration{{Key: "robots", Effect: corev1.TaintEffectNoSchedule}},
+				PriorityClassName: "low",
+				SpreadAcrossZones: true,
+			},
+		},
+		{
+			name: "the Robot wins",
+			spec: robotv1.RobotSpec{
+				Image:             "robot:canary",
+				Labels:            map[string]string{"tier": "frontend"},
+				Tolerations:       []corev1.Toleration{},
+				PriorityClassName: "high",
+				SpreadAcrossNodes: true,
+			},
+			want: robotv1.RobotSpec{
+				Image:             "robot:canary",
+				Labels:            map[string]string{"team": "robots", "tier": "frontend"},
+				NodeSelector:      map[string]string{"pool": "robots"},
+				Tolerations:       []corev1.Toleration{},
+				PriorityClassName: "high",
+				SpreadAcrossZones: true,
+				SpreadAcrossNodes: true,
+			},
+		},
+		{
+			name: "registry prefixes every image",
+			spec: robotv1.RobotSpec{
+				Sidecars:       []corev1.Container{{Name: "proxy", Image: "envoy"}, {Name: "logs", Image: "quay.io/fluent-bit"}},
+				InitContainers: []corev1.Container{{Name: "migrate", Image: "migrate"}},
+			},
+			registry: "registry.example.com",
+			want: robotv1.RobotSpec{
+				Image:             "registry.example.com/robot:stable",
+				Labels:            map[string]string{"team": "robots", "tier": "backend"},
+				NodeSelector:      map[string]string{"pool": "robots"},
+				Tolerations:       []corev1.Toleration{{Key: "robots", Effect: corev1.TaintEffectNoSchedule}},
+				PriorityClassName: "low",
+				SpreadAcrossZones: true,
+				Sidecars:          []corev1.Container{{Name: "proxy", Image: "registry.example.com/envoy"}, {Name: "logs", Image: "quay.io/fluent-bit"}},
+				InitContainers:    []corev1.Container{{Name: "migrate", Image: "registry.example.com/migrate"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			class := class.DeepCopy()
+			class.Spec.ImageRegistry = tt.registry
+			robot := &robotv1.Robot{Spec: tt.spec}
+
+			got := mergeClass(robot, class)
+			if !reflect.DeepEqual(got.Spec, tt.want) {
+				t.Errorf("got %+v, want %+v", got.Spec, tt.want)
+			}
+			if !reflect.DeepEqual(robot.Spec, tt.spec) {
+				t.Errorf("the Robot was changed: %+v", robot.Spec)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+// getCondition returns the condition of status with the given type, nil if
+// there is none.
+func getCondition(status *robotv1.RobotStatus, conditionType robotv1.RobotConditionType) *robotv1.RobotCondition {
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == conditionType {
+			return &status.Conditions[i]
+		}
+	}
+
+	return nil
+}
+
+// setCondition adds or updates the condition of status with the given type.
+// The transition time only moves when the condition status changes.
+func setCondition(status *robotv1.RobotStatus, conditionType robotv1.RobotConditionType, conditionStatus corev1.ConditionStatus, reason, message string) {
+	condition := getCondition(status, conditionType)
+	if condition == nil {
+		status.Conditions = append(status.Conditions, robotv1.RobotCondition{Type: conditionType})
+		condition = &status.Conditions[len(status.Conditions)-1]
+	}
+
+	if condition.Status != conditionStatus {
+		condition.Status = conditionStatus
+		condition.LastTransitionTime = metav1.Now()
+	}
+	condition.Reason = reason
+	condition.Message = message
+}
+
+// removeCondition removes the condition of status with the given type.
+func removeCondition(status *robotv1.RobotStatus, conditionType robotv1.RobotConditionType) {
+	conditions := status.Conditions[:0]
+	for _, condition := range status.Conditions {
+		if condition.Type != conditionType {
+			conditions = append(conditions, condition)
+		}
+	}
+
+	if len(conditions) == 0 {
+		conditions = nil
+	}
+	status.Conditions = conditions
+}
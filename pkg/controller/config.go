@@ -0,0 +1,347 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+const (
+	// configMapIndex and secretIndex index Robots by the namespace/name keys
+	// of the ConfigMaps and Secrets they reference.
+	configMapIndex = "configMap"
+	secretIndex    = "secret"
+
+	// configHashAnnotation records on the pod template the hash of the
+	// configuration the Robot references, so that changing it rolls the pods.
+	configHashAnnotation = "robot.llleon.io/config-hash"
+
+	configVolumeName       = "robot-config"
+	defaultConfigMountPath = "/etc/robot"
+
+	ConfigInvalid        = "ConfigInvalid"
+	ErrConfigRef         = "ErrConfigRef"
+	MessageConfigInvalid = "Error rendering config: %s"
+	MessageConfigRef     = "Config reference %d must name exactly one of a ConfigMap and a Secret"
+)
+
+// indexRobotConfigMaps is a cache.IndexFunc returning the keys of the
+// ConfigMaps referenced by a Robot.
+func indexRobotConfigMaps(obj interface{}) ([]string, error) {
+	robot, ok := obj.(*robotv1.Robot)
+	if !ok {
+		return nil, nil
+	}
+
+	var keys []string
+	for _, ref := range robot.Spec.ConfigRefs {
+		if ref.ConfigMapName != "" {
+			keys = append(keys, robot.Namespace+"/"+ref.ConfigMapName)
+		}
+	}
+
+	return keys, nil
+}
+
+// indexRobotSecrets is a cache.IndexFunc returning the keys of the Secrets
+// referenced by a Robot.
+func indexRobotSecrets(obj interface{}) ([]string, error) {
+	robot, ok := obj.(*robotv1.Robot)
+	if !ok {
+		return nil, nil
+	}
+
+	var keys []string
+	for _, ref := range robot.Spec.ConfigRefs {
+		if ref.SecretName != "" {
+			keys = append(keys, robot.Namespace+"/"+ref.SecretName)
+		}
+	}
+
+	return keys, nil
+}
+
+// handleConfig enqueues the Robot owning the ConfigMap or Secret obj, and
+// every Robot referencing it.
+func (c *Controller) handleConfig(obj interface{}) {
+	c.handleObject(obj)
+
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	var index string
+	switch obj.(type) {
+	case *corev1.ConfigMap:
+		index = configMapIndex
+	case *corev1.Secret:
+		index = secretIndex
+	default:
+		utilruntime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+		return
+	}
+
+	object := obj.(metav1.Object)
+	key := object.GetNamespace() + "/" + object.GetName()
+	robots, err := c.robotsIndexer.ByIndex(index, key)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+
+	for _, robot := range robots {
+		klog.V(4).Infof("%s '%s' changed, enqueueing Robot", index, key)
+		c.enqueueRobot(robot)
+	}
+}
+
+// invalidConfigRef returns why a config reference of robot is invalid, "" if
+// none is.
+func invalidConfigRef(robot *robotv1.Robot) string {
+	for i, ref := range robot.Spec.ConfigRefs {
+		if (ref.ConfigMapName == "") == (ref.SecretName == "") {
+			return fmt.Sprintf(MessageConfigRef, i)
+		}
+	}
+
+	return ""
+}
+
+// configHash returns a hash of managed, the data of the ConfigMap and Secret
+// owned by robot, and of the data of every ConfigMap and Secret robot
+// references, or "" if there is no configuration at all. A missing object is
+// hashed as such, so creating it later still restarts the pods.
+func (c *Controller) configHash(robot *robotv1.Robot, managed ...interface{}) (string, error) {
+	if len(robot.Spec.ConfigRefs) == 0 && len(managed) == 0 {
+		return "", nil
+	}
+
+	hasher := fnv.New32a()
+	for _, data := range managed {
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprint(hasher, "managed:")
+		hasher.Write(encoded)
+	}
+
+	for _, ref := range robot.Spec.ConfigRefs {
+		var data interface{}
+
+		switch {
+		case ref.ConfigMapName != "":
+			configMap, err := c.configMapsLister.ConfigMaps(robot.Namespace).Get(ref.ConfigMapName)
+			if err != nil && !errors.IsNotFound(err) {
+				return "", err
+			}
+			if configMap != nil {
+				data = []interface{}{configMap.Data, configMap.BinaryData}
+			}
+			fmt.Fprintf(hasher, "configmap/%s:", ref.ConfigMapName)
+		case ref.SecretName != "":
+			secret, err := c.secretsLister.Secrets(robot.Namespace).Get(ref.SecretName)
+			if err != nil && !errors.IsNotFound(err) {
+				return "", err
+			}
+			if secret != nil {
+				data = secret.Data
+			}
+			fmt.Fprintf(hasher, "secret/%s:", ref.SecretName)
+		}
+
+		// json.Marshal sorts map keys, which keeps the hash stable
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			return "", err
+		}
+		hasher.Write(encoded)
+	}
+
+	return rand.SafeEncodeString(fmt.Sprint(hasher.Sum32())), nil
+}
+
+// configVolumes returns the volumes, mounts and environment sources that
+// expose the configuration referenced by robot to its container.
+func configVolumes(robot *robotv1.Robot) ([]corev1.Volume, []corev1.VolumeMount, []corev1.EnvFromSource) {
+	var (
+		volumes []corev1.Volume
+		mounts  []corev1.VolumeMount
+		envFrom []corev1.EnvFromSource
+	)
+
+	for i, ref := range robot.Spec.ConfigRefs {
+		if ref.MountPath == "" {
+			switch {
+			case ref.ConfigMapName != "":
+				envFrom = append(envFrom, corev1.EnvFromSource{
+					ConfigMapRef: &corev1.ConfigMapEnvSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: ref.ConfigMapName},
+					},
+				})
+			case ref.SecretName != "":
+				envFrom = append(envFrom, corev1.EnvFromSource{
+					SecretRef: &corev1.SecretEnvSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: ref.SecretName},
+					},
+				})
+			}
+			continue
+		}
+
+		volume := corev1.Volume{Name: fmt.Sprintf("config-%d", i)}
+		switch {
+		case ref.ConfigMapName != "":
+			volume.ConfigMap = &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: ref.ConfigMapName},
+			}
+		case ref.SecretName != "":
+			volume.Secret = &corev1.SecretVolumeSource{SecretName: ref.SecretName}
+		default:
+			continue
+		}
+
+		volumes = append(volumes, volume)
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      volume.Name,
+			MountPath: ref.MountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	if robot.Spec.Config != nil {
+		mountPath := robot.Spec.Config.MountPath
+		if mountPath == "" {
+			mountPath = defaultConfigMountPath
+		}
+
+		volumes = append(volumes, corev1.Volume{
+			Name: configVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: configMapName(robot)},
+				},
+			},
+		})
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      configVolumeName,
+			MountPath: mountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	return volumes, mounts, envFrom
+}
+
+// configMapName returns the name of the ConfigMap owned by robot.
+func configMapName(robot *robotv1.Robot) string {
+	return robot.Name + "-config"
+}
+
+// syncConfigMap makes the ConfigMap owned by robot hold its rendered
+// spec.config files, deleting it once spec.config is removed, and returns the
+// rendered data. Files that fail to render leave the ConfigMap as it is and
+// flag status with the ConfigInvalid condition, which retrying does not fix.
+func (c *Controller) syncConfigMap(robot *robotv1.Robot, status *robotv1.RobotStatus) (map[string]string, error) {
+	name := configMapName(robot)
+	configMap, err := c.configMapsLister.ConfigMaps(robot.Namespace).Get(name)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	if robot.Spec.Config == nil {
+		removeCondition(status, robotv1.RobotConfigInvalid)
+		if configMap != nil && metav1.IsControlledBy(configMap, robot) {
+			err = c.kubeClientset.CoreV1().ConfigMaps(robot.Namespace).Delete(context.TODO(), name, c.deleteOptions(robot, "ConfigMap", name))
+			if err != nil && !errors.IsNotFound(err) {
+				return nil, err
+			}
+		}
+		return nil, nil
+	}
+
+	data, err := renderConfig(robot)
+	if err != nil {
+		msg := fmt.Sprintf(MessageConfigInvalid, err.Error())
+		if condition := getCondition(status, robotv1.RobotConfigInvalid); condition == nil || condition.Message != msg {
+			c.recorder.Event(robot, corev1.EventTypeWarning, ConfigInvalid, msg)
+		}
+		setCondition(status, robotv1.RobotConfigInvalid, corev1.ConditionTrue, ConfigInvalid, msg)
+		return nil, nil
+	}
+	removeCondition(status, robotv1.RobotConfigInvalid)
+
+	if configMap == nil {
+		_, err = c.kubeClientset.CoreV1().ConfigMaps(robot.Namespace).Create(context.TODO(), newConfigMap(robot, data), c.createOptions(robot, "ConfigMap", name))
+		return data, err
+	}
+
+	if !metav1.IsControlledBy(configMap, robot) {
+		msg := fmt.Sprintf(MessageResourceExists, name)
+		c.recorder.Event(robot, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return nil, fmt.Errorf(msg)
+	}
+
+	if !reflect.DeepEqual(configMap.Data, data) || metadataChanged(configMap, robot) {
+		configMapCopy := configMap.DeepCopy()
+		configMapCopy.Data = data
+		applyMetadata(configMapCopy, robot)
+		_, err = c.kubeClientset.CoreV1().ConfigMaps(robot.Namespace).Update(context.TODO(), configMapCopy, c.updateOptions(robot, "ConfigMap", name))
+	}
+
+	return data, err
+}
+
+// renderConfig returns the files of robot's spec.config, executing them as
+// templates if asked to.
+func renderConfig(robot *robotv1.Robot) (map[string]string, error) {
+	data := make(map[string]string, len(robot.Spec.Config.Files))
+	if !robot.Spec.Config.Template {
+		for file, content := range robot.Spec.Config.Files {
+			data[file] = content
+		}
+		return data, nil
+	}
+
+	values := struct {
+		Name        string
+		Namespace   string
+		Labels      map[string]string
+		Annotations map[string]string
+	}{robot.Name, robot.Namespace, robot.Labels, robot.Annotations}
+
+	for file, content := range robot.Spec.Config.Files {
+		var buf bytes.Buffer
+		tmpl, err := template.New(file).Option("missingkey=error").Parse(content)
+		if err == nil {
+			err = tmpl.Execute(&buf, values)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("file %q: %s", file, err.Error())
+		}
+		data[file] = buf.String()
+	}
+
+	return data, nil
+}
+
+func newConfigMap(robot *robotv1.Robot, data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: objectMeta(robot, configMapName(robot)),
+		Data:       data,
+	}
+}
@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+func TestInvalidConfigRef(t *testing.T) {
+	tests := []struct {
+		name string
+		refs []robotv1.ConfigRef
+		want string
+	}{
+		{
+			name: "no references",
+		},
+		{
+			name: "ConfigMap and Secret",
+			refs: []robotv1.ConfigRef{{ConfigMapName: "settings"}, {SecretName: "credentials"}},
+		},
+		{
+			name: "neither",
+			refs: []robotv1.ConfigRef{{ConfigMapName: "settings"}, {}},
+			want: fmt.Sprintf(MessageConfigRef, 1),
+		},
+		{
+			name: "both",
+			refs: []robotv1.ConfigRef{{ConfigMapName: "settings", SecretName: "credentials"}},
+			want: fmt.Sprintf(MessageConfigRef, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			robot := &robotv1.Robot{Spec: robotv1.RobotSpec{ConfigRefs: tt.refs}}
+
+			if got := invalidConfigRef(robot); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
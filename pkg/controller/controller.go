@@ -2,30 +2,44 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"hash/fnv"
+	"strings"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/rand"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	appsinformers "k8s.io/client-go/informers/apps/v1"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	networkinginformers "k8s.io/client-go/informers/networking/v1"
+	rbacinformers "k8s.io/client-go/informers/rbac/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	appslister "k8s.io/client-go/listers/apps/v1"
+	corelister "k8s.io/client-go/listers/core/v1"
+	networkinglister "k8s.io/client-go/listers/networking/v1"
+	rbaclister "k8s.io/client-go/listers/rbac/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 
 	robotv1 "robot-operator/pkg/apis/robot/v1"
+	"robot-operator/pkg/config"
 	clientset "robot-operator/pkg/generated/clientset/versioned"
 	robotscheme "robot-operator/pkg/generated/clientset/versioned/scheme"
 	robotinformers "robot-operator/pkg/generated/informers/externalversions/robot/v1"
 	robotlisters "robot-operator/pkg/generated/listers/robot/v1"
+	"robot-operator/pkg/prometheus"
 )
 
 const (
@@ -35,17 +49,61 @@ const (
 	ErrResourceExists     = "ErrResourceExists"
 	MessageResourceExists = "Resource %q already exists and is not managed by Robot"
 	MessageResourceSynced = "Robot synced successfully"
+
+	DeploymentReplaced        = "DeploymentReplaced"
+	MessageDeploymentReplaced = "Deployment %q deleted to be recreated selecting the pods of the Robot by its labels, its pods run until then"
+
+	// templateHashAnnotation records on a Deployment the hash of the pod
+	// template last rendered for it from the Robot.
+	templateHashAnnotation = "robot.llleon.io/template-hash"
+
+	defaultImage = "nginx:latest"
 )
 
 type Controller struct {
 	kubeClientset  kubernetes.Interface
 	robotClientset clientset.Interface
 
-	deploymentsLister appslister.DeploymentLister
-	robotsLister      robotlisters.RobotLister
-
-	deploymentsSynced cache.InformerSynced
-	robotsSynced      cache.InformerSynced
+	deploymentsLister          appslister.DeploymentLister
+	replicaSetsLister          appslister.ReplicaSetLister
+	configMapsLister           corelister.ConfigMapLister
+	secretsLister              corelister.SecretLister
+	serviceAccountsLister      corelister.ServiceAccountLister
+	claimsLister               corelister.PersistentVolumeClaimLister
+	rolesLister                rbaclister.RoleLister
+	roleBindingsLister         rbaclister.RoleBindingLister
+	networkPoliciesLister      networkinglister.NetworkPolicyLister
+	podsLister                 corelister.PodLister
+	robotsLister               robotlisters.RobotLister
+	robotClassesLister         robotlisters.RobotClassLister
+	robotPoliciesLister        robotlisters.RobotPolicyLister
+	clusterRobotPoliciesLister robotlisters.ClusterRobotPolicyLister
+	robotQuotasLister          robotlisters.RobotQuotaLister
+
+	deploymentsSynced          cache.InformerSynced
+	replicaSetsSynced          cache.InformerSynced
+	configMapsSynced           cache.InformerSynced
+	secretsSynced              cache.InformerSynced
+	serviceAccountsSynced      cache.InformerSynced
+	claimsSynced               cache.InformerSynced
+	rolesSynced                cache.InformerSynced
+	roleBindingsSynced         cache.InformerSynced
+	networkPoliciesSynced      cache.InformerSynced
+	podsSynced                 cache.InformerSynced
+	robotsSynced               cache.InformerSynced
+	robotClassesSynced         cache.InformerSynced
+	robotPoliciesSynced        cache.InformerSynced
+	clusterRobotPoliciesSynced cache.InformerSynced
+	robotQuotasSynced          cache.InformerSynced
+
+	// robotsIndexer looks up Robots by the objects they reference.
+	robotsIndexer cache.Indexer
+
+	// prometheus evaluates rollout analysis queries, nil if not configured.
+	prometheus prometheus.Interface
+	config     *config.Config
+	// dryRunner reports writes instead of making them in dry-run mode.
+	dryRunner
 
 	workQueue workqueue.RateLimitingInterface
 	recorder  record.EventRecorder
@@ -55,7 +113,23 @@ func NewController(
 	kubeClientset kubernetes.Interface,
 	robotClientset clientset.Interface,
 	deploymentInformer appsinformers.DeploymentInformer,
-	robotInformer robotinformers.RobotInformer) *Controller {
+	replicaSetInformer appsinformers.ReplicaSetInformer,
+	configMapInformer coreinformers.ConfigMapInformer,
+	secretInformer coreinformers.SecretInformer,
+	serviceAccountInformer coreinformers.ServiceAccountInformer,
+	claimInformer coreinformers.PersistentVolumeClaimInformer,
+	roleInformer rbacinformers.RoleInformer,
+	roleBindingInformer rbacinformers.RoleBindingInformer,
+	networkPolicyInformer networkinginformers.NetworkPolicyInformer,
+	podInformer coreinformers.PodInformer,
+	robotInformer robotinformers.RobotInformer,
+	robotClassInformer robotinformers.RobotClassInformer,
+	robotPolicyInformer robotinformers.RobotPolicyInformer,
+	clusterRobotPolicyInformer robotinformers.ClusterRobotPolicyInformer,
+	robotQuotaInformer robotinformers.RobotQuotaInformer,
+	prometheusClient prometheus.Interface,
+	cfg *config.Config,
+	dryRun bool) *Controller {
 
 	// Add robot-operator types to the default Kubernetes Scheme so Events can be
 	// logged for robot-operator types.
@@ -69,14 +143,44 @@ func NewController(
 	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: controllerAgentName})
 
 	controller := &Controller{
-		kubeClientset:     kubeClientset,
-		robotClientset:    robotClientset,
-		deploymentsLister: deploymentInformer.Lister(),
-		robotsLister:      robotInformer.Lister(),
-		deploymentsSynced: deploymentInformer.Informer().HasSynced,
-		robotsSynced:      robotInformer.Informer().HasSynced,
-		workQueue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "Robots"),
-		recorder:          recorder,
+		kubeClientset:              kubeClientset,
+		robotClientset:             robotClientset,
+		deploymentsLister:          deploymentInformer.Lister(),
+		replicaSetsLister:          replicaSetInformer.Lister(),
+		configMapsLister:           configMapInformer.Lister(),
+		secretsLister:              secretInformer.Lister(),
+		serviceAccountsLister:      serviceAccountInformer.Lister(),
+		claimsLister:               claimInformer.Lister(),
+		rolesLister:                roleInformer.Lister(),
+		roleBindingsLister:         roleBindingInformer.Lister(),
+		networkPoliciesLister:      networkPolicyInformer.Lister(),
+		podsLister:                 podInformer.Lister(),
+		robotsLister:               robotInformer.Lister(),
+		robotClassesLister:         robotClassInformer.Lister(),
+		robotPoliciesLister:        robotPolicyInformer.Lister(),
+		clusterRobotPoliciesLister: clusterRobotPolicyInformer.Lister(),
+		robotQuotasLister:          robotQuotaInformer.Lister(),
+		deploymentsSynced:          deploymentInformer.Informer().HasSynced,
+		replicaSetsSynced:          replicaSetInformer.Informer().HasSynced,
+		configMapsSynced:           configMapInformer.Informer().HasSynced,
+		secretsSynced:              secretInformer.Informer().HasSynced,
+		serviceAccountsSynced:      serviceAccountInformer.Informer().HasSynced,
+		claimsSynced:               claimInformer.Informer().HasSynced,
+		rolesSynced:                roleInformer.Informer().HasSynced,
+		roleBindingsSynced:         roleBindingInformer.Informer().HasSynced,
+		networkPoliciesSynced:      networkPolicyInformer.Informer().HasSynced,
+		podsSynced:                 podInformer.Informer().HasSynced,
+		robotsSynced:               robotInformer.Informer().HasSynced,
+		robotClassesSynced:         robotClassInformer.Informer().HasSynced,
+		robotPoliciesSynced:        robotPolicyInformer.Informer().HasSynced,
+		clusterRobotPoliciesSynced: clusterRobotPolicyInformer.Informer().HasSynced,
+		robotQuotasSynced:          robotQuotaInformer.Informer().HasSynced,
+		robotsIndexer:              robotInformer.Informer().GetIndexer(),
+		prometheus:                 prometheusClient,
+		config:                     cfg,
+		dryRunner:                  dryRunner{dryRun: dryRun, events: recorder},
+		workQueue:                  workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "Robots"),
+		recorder:                   recorder,
 	}
 
 	// set up an event handler for when Deployment resources change
@@ -90,17 +194,135 @@ func NewController(
 				return
 			}
 
+			controller.handleObject(new)
+			// the replicas a Robot runs count against the quotas of its peers
+			if !equality.Semantic.DeepEqual(oldDepl.Spec.Replicas, newDepl.Spec.Replicas) {
+				controller.handleQuotaDeployment(new)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			controller.handleObject(obj)
+			controller.handleQuotaDeployment(obj)
+		},
+	})
+
+	// set up an event handler for when objects owned by Robots change
+	ownedHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: controller.handleObject,
+		UpdateFunc: func(old, new interface{}) {
+			if old.(metav1.Object).GetResourceVersion() == new.(metav1.Object).GetResourceVersion() {
+				return
+			}
+
 			controller.handleObject(new)
 		},
 		DeleteFunc: controller.handleObject,
+	}
+	serviceAccountInformer.Informer().AddEventHandler(ownedHandler)
+	claimInformer.Informer().AddEventHandler(ownedHandler)
+	roleInformer.Informer().AddEventHandler(ownedHandler)
+	roleBindingInformer.Informer().AddEventHandler(ownedHandler)
+	networkPolicyInformer.Informer().AddEventHandler(ownedHandler)
+
+	// set up an event handler for when the pods of Robots change, the pod
+	// informer being restricted to them
+	podInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: controller.handlePod,
+		UpdateFunc: func(old, new interface{}) {
+			if old.(metav1.Object).GetResourceVersion() == new.(metav1.Object).GetResourceVersion() {
+				return
+			}
+
+			controller.handlePod(new)
+		},
+		DeleteFunc: controller.handlePod,
 	})
 
+	// set up event handlers for when ConfigMaps or Secrets referenced by
+	// Robots change
+	utilruntime.Must(robotInformer.Informer().AddIndexers(cache.Indexers{
+		configMapIndex:   indexRobotConfigMaps,
+		secretIndex:      indexRobotSecrets,
+		networkPeerIndex: indexRobotNetworkPeers,
+		dependencyIndex:  indexRobotDependencies,
+		classIndex:       indexRobotClasses,
+	}))
+	configHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: controller.handleConfig,
+		UpdateFunc: func(old, new interface{}) {
+			if old.(metav1.Object).GetResourceVersion() == new.(metav1.Object).GetResourceVersion() {
+				return
+			}
+
+			controller.handleConfig(new)
+		},
+		DeleteFunc: controller.handleConfig,
+	}
+	configMapInformer.Informer().AddEventHandler(configHandler)
+	secretInformer.Informer().AddEventHandler(configHandler)
+
 	// set up an event handler for when Robot resources change
 	robotInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc: controller.enqueueRobot,
+		AddFunc: func(obj interface{}) {
+			controller.enqueueRobot(obj)
+			controller.enqueueDependents(obj)
+			controller.enqueueQuotaPeers(obj)
+		},
 		UpdateFunc: func(old, new interface{}) {
 			controller.enqueueRobot(new)
+			controller.enqueueDependents(new)
+			if quotaReplicas(old.(*robotv1.Robot)) != quotaReplicas(new.(*robotv1.Robot)) {
+				controller.enqueueQuotaPeers(new)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			controller.enqueueDependents(obj)
+			controller.enqueueQuotaPeers(obj)
+		},
+	})
+
+	// set up an event handler for when RobotClass resources change
+	robotClassInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: controller.handleClass,
+		UpdateFunc: func(old, new interface{}) {
+			if old.(metav1.Object).GetResourceVersion() == new.(metav1.Object).GetResourceVersion() {
+				return
+			}
+
+			controller.handleClass(new)
+		},
+		DeleteFunc: controller.handleClass,
+	})
+
+	// set up an event handler for when RobotPolicy or ClusterRobotPolicy
+	// resources change
+	policyHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: controller.handlePolicy,
+		UpdateFunc: func(old, new interface{}) {
+			if old.(metav1.Object).GetResourceVersion() == new.(metav1.Object).GetResourceVersion() {
+				return
+			}
+
+			controller.handlePolicy(new)
+		},
+		DeleteFunc: controller.handlePolicy,
+	}
+	robotPolicyInformer.Informer().AddEventHandler(policyHandler)
+	clusterRobotPolicyInformer.Informer().AddEventHandler(policyHandler)
+
+	// set up an event handler for when RobotQuota resources change
+	robotQuotaInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: controller.handleQuota,
+		UpdateFunc: func(old, new interface{}) {
+			oldQuota := old.(*robotv1.RobotQuota)
+			newQuota := new.(*robotv1.RobotQuota)
+			if equality.Semantic.DeepEqual(oldQuota.Spec, newQuota.Spec) {
+				return
+			}
+
+			controller.handleQuota(new)
 		},
+		DeleteFunc: controller.handleQuota,
 	})
 
 	return controller
@@ -114,7 +336,10 @@ func (c *Controller) Run(threadness int, stopCh <-chan struct{}) error {
 
 	// wait for the caches to be synced before starting workers
 	klog.Info("Waiting for informer caches to sync")
-	if ok := cache.WaitForCacheSync(stopCh, c.deploymentsSynced, c.robotsSynced); !ok {
+	if ok := cache.WaitForCacheSync(stopCh, c.deploymentsSynced, c.replicaSetsSynced, c.configMapsSynced, c.secretsSynced,
+		c.serviceAccountsSynced, c.claimsSynced, c.rolesSynced, c.roleBindingsSynced, c.networkPoliciesSynced, c.podsSynced, c.robotsSynced,
+		c.robotClassesSynced, c.robotPoliciesSynced, c.clusterRobotPoliciesSynced,
+		c.robotQuotasSynced); !ok {
 		return fmt.Errorf("failed to wait for caches to sync")
 	}
 
@@ -192,7 +417,8 @@ func (c *Controller) reconcile(key string) error {
 	if err != nil {
 		if errors.IsNotFound(err) {
 			utilruntime.HandleError(fmt.Errorf("foo '%s' in work queue no longer exists", key))
-			return nil
+			// the Robot no longer counts against the quotas of its namespace
+			return c.syncQuotaStatus(namespace)
 		}
 
 		return err
@@ -205,28 +431,177 @@ func (c *Controller) reconcile(key string) error {
 		return nil
 	}
 
+	// the status is built up along the way and written back at the end
+	status := robot.Status.DeepCopy()
+
+	// a paused Robot leaves its objects alone, e.g. while they are edited
+	// by hand during an incident, but still reports on them
+	if paused(robot) {
+		setCondition(status, robotv1.RobotPaused, corev1.ConditionTrue, RobotPaused, MessageRobotPaused)
+		deployment, err := c.deploymentsLister.Deployments(robot.Namespace).Get(deploymentName)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		if deployment != nil {
+			status.AvailableReplicas = deployment.Status.AvailableReplicas
+		}
+		if status.Pods, err = c.podsStatus(robot); err != nil {
+			return err
+		}
+		return c.updateRobotStatus(robot, status)
+	}
+	removeCondition(status, robotv1.RobotPaused)
+
+	// delete the Robot once it expires, even if a rule, a policy or its class
+	// holds it back, and come back when it is about to expire, then expires
+	now := time.Now()
+	expired, expiryCheck, err := c.syncExpiry(robot, status, now)
+	if err != nil || expired {
+		return err
+	}
+	if expiryCheck > 0 {
+		c.workQueue.AddAfter(key, expiryCheck)
+	}
+
+	// refuse to reconcile a Robot whose config references are ambiguous,
+	// which older versions of the CRD let through
+	if msg := invalidConfigRef(robot); msg != "" {
+		c.recorder.Event(robot, corev1.EventTypeWarning, ErrConfigRef, msg)
+		return nil
+	}
+
+	// refuse to reconcile a Robot failing a rule of the operator config,
+	// leaving its objects as they are
+	ruleViolations, err := c.evaluateRules(robot)
+	if err != nil {
+		return err
+	}
+	if len(ruleViolations) > 0 {
+		// count the denial once, when the Robot starts failing its rules
+		if condition := getCondition(status, robotv1.RobotRuleViolation); condition == nil || condition.Status != corev1.ConditionTrue {
+			countRuleDenials(ruleViolations, reconcileSource)
+		}
+		message := fmt.Sprintf(MessageRuleViolation, strings.Join(ruleMessages(ruleViolations), "; "))
+		setCondition(status, robotv1.RobotRuleViolation, corev1.ConditionTrue, RuleViolation, message)
+		c.recorder.Event(robot, corev1.EventTypeWarning, RuleViolation, message)
+		return c.updateRobotStatus(robot, status)
+	}
+	removeCondition(status, robotv1.RobotRuleViolation)
+
+	// take the defaults of the Robot's class, waiting for it to exist
+	robot, err = c.applyClass(robot)
+	if err != nil || robot == nil {
+		return err
+	}
+
+	// refuse to render a Robot violating a policy, leaving its objects as
+	// they are
+	violations, err := c.policyViolations(robot)
+	if err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		message := fmt.Sprintf(MessagePolicyViolation, strings.Join(violations, "; "))
+		setCondition(status, robotv1.RobotPolicyViolation, corev1.ConditionTrue, PolicyViolation, message)
+		c.recorder.Event(robot, corev1.EventTypeWarning, PolicyViolation, message)
+		return c.updateRobotStatus(robot, status)
+	}
+	removeCondition(status, robotv1.RobotPolicyViolation)
+
+	// set up the identity the Robot's pods run as
+	if err := c.syncServiceAccount(robot, status); err != nil {
+		return err
+	}
+
+	// isolate the Robot's pods
+	if err := c.syncNetworkPolicy(robot); err != nil {
+		return err
+	}
+
+	// provision the Robot's persistent storage
+	if err := c.syncClaim(robot); err != nil {
+		return err
+	}
+
+	// render the configuration files and credentials owned by the Robot
+	var managed []interface{}
+	configData, err := c.syncConfigMap(robot, status)
+	if err != nil {
+		return err
+	}
+	// leave the Deployment of a Robot whose config does not render as it is
+	if getCondition(status, robotv1.RobotConfigInvalid) != nil {
+		return c.updateRobotStatus(robot, status)
+	}
+	if configData != nil {
+		managed = append(managed, configData)
+	}
+
+	secretData, err := c.syncSecret(robot)
+	if err != nil {
+		return err
+	}
+	if secretData != nil {
+		managed = append(managed, secretData)
+	}
+
+	// hash the configuration so that changing it rolls the pods
+	configHash, err := c.configHash(robot, managed...)
+	if err != nil {
+		return err
+	}
+
 	deployment, err := c.deploymentsLister.Deployments(robot.Namespace).Get(deploymentName)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
 
-	// if the resource doesn't exist, create it
-	if errors.IsNotFound(err) {
-		deployment, err = c.kubeClientset.AppsV1().Deployments(robot.Namespace).Create(context.TODO(), newDeployment(robot), metav1.CreateOptions{})
+	// check whether deployment is controlled by robot, adopting it if asked to
+	if deployment != nil && !metav1.IsControlledBy(deployment, robot) {
+		if !adoptExisting(robot) {
+			msg := fmt.Sprintf(MessageResourceExists, deployment.Name)
+			c.recorder.Event(robot, corev1.EventTypeWarning, ErrResourceExists, msg)
+			return fmt.Errorf(msg)
+		}
+
+		deployment, err = c.adoptDeployment(robot, deployment, configHash)
+		if err != nil {
+			return err
+		}
 	}
 
-	// requeue the item
+	// the schedule in effect and the activity of the Robot decide the
+	// replicas to run
+	schedule := c.syncSchedules(robot, status, now)
+	idle, idleCheck := c.syncIdle(robot, status, now)
+	replicas := c.desiredReplicas(robot, schedule, idle, deployment, status)
+
+	// hold the Robot back until the Robots it depends on are up
+	waiting, err := c.syncDependencies(robot, status)
 	if err != nil {
 		return err
 	}
+	if waiting && deployment == nil {
+		status.AvailableReplicas = 0
+		return c.updateRobotStatus(robot, status)
+	}
+	if waiting {
+		replicas = holdReplicas(replicas, deployment)
+	}
 
-	// check whether deployment is controlled by robot
-	if !metav1.IsControlledBy(deployment, robot) {
-		msg := fmt.Sprintf(MessageResourceExists, deployment.Name)
-		c.recorder.Event(robot, corev1.EventTypeWarning, ErrResourceExists, msg)
-		return fmt.Errorf(msg)
+	// keep the Robot within the quotas of its namespace
+	replicas, err = c.syncQuota(robot, status, replicas, deployment)
+	if err != nil {
+		return err
 	}
 
-	if robot.Spec.Replicas != nil && *robot.Spec.Replicas != *deployment.Spec.Replicas {
-		klog.V(4).Infof("Robot %s replicas: %d, Deployment %s replicas: %d", robot.Name, *robot.Spec.Replicas, deployment.Name, *deployment.Spec.Replicas)
-		deployment, err = c.kubeClientset.AppsV1().Deployments(robot.Namespace).Update(context.TODO(), newDeployment(robot), metav1.UpdateOptions{})
+	// if the resource doesn't exist, create it
+	if deployment == nil {
+		desired := newDeployment(robot, configHash, c.config.DefaultSidecars)
+		desired.Spec.Replicas = replicas
+		deployment, err = c.kubeClientset.AppsV1().Deployments(robot.Namespace).Create(context.TODO(), desired, c.createOptions(robot, "Deployment", deploymentName))
+	} else {
+		deployment, err = c.syncDeployment(robot, deployment, configHash, replicas)
 	}
 
 	// requeue the item
@@ -234,17 +609,154 @@ func (c *Controller) reconcile(key string) error {
 		return err
 	}
 
+	// remove the pods of a replaced legacy Deployment once they are replaced
+	if err := c.cleanupLegacyReplicaSets(robot, deployment); err != nil {
+		return err
+	}
+
+	// gate the rollout of the current template on its analysis
+	analysis, err := c.analyzeRollout(robot, deployment)
+	if err != nil {
+		return err
+	}
+
 	// update the status block of the Robot resource
-	err = c.updateRobotStatus(robot, deployment)
+	status.AvailableReplicas = deployment.Status.AvailableReplicas
+	status.Analysis = analysis
+	if status.Pods, err = c.podsStatus(robot); err != nil {
+		return err
+	}
+	err = c.updateRobotStatus(robot, status)
 	if err != nil {
 		return err
 	}
 
+	// keep evaluating while the rollout is in progress
+	if analysis != nil && analysis.Phase == robotv1.AnalysisPhaseRunning {
+		c.workQueue.AddAfter(key, analysisInterval(robot.Spec.Analysis))
+	}
+
+	// come back when the schedules change the replicas
+	if status.NextScheduleTransition != nil {
+		c.workQueue.AddAfter(key, time.Until(status.NextScheduleTransition.Time))
+	}
+
+	// and when the Robot may turn idle
+	if idleCheck > 0 {
+		c.workQueue.AddAfter(key, idleCheck)
+	}
+
 	c.recorder.Event(robot, corev1.EventTypeNormal, SuccessSynced, MessageResourceSynced)
 
 	return nil
 }
 
+// syncDeployment updates deployment when its replicas, pod template or paused
+// state drift from what robot asks for. Nil replicas leave the replicas of
+// deployment as they are.
+func (c *Controller) syncDeployment(robot *robotv1.Robot, deployment *appsv1.Deployment, configHash string, replicas *int32) (*appsv1.Deployment, error) {
+	desired := newDeployment(robot, configHash, c.config.DefaultSidecars)
+	desired.Spec.Replicas = deployment.Spec.Replicas
+	if replicas != nil {
+		desired.Spec.Replicas = replicas
+	}
+
+	// the selector of a Deployment is immutable: one created with the legacy
+	// selector is replaced, while an adopted one keeps its selector, and its
+	// pods keep the labels it selects on
+	if !equality.Semantic.DeepEqual(deployment.Spec.Selector, desired.Spec.Selector) {
+		if equality.Semantic.DeepEqual(deployment.Spec.Selector, legacySelector(robot)) {
+			return c.replaceDeployment(robot, deployment)
+		}
+		desired.Spec.Selector = deployment.Spec.Selector
+		desired.Spec.Template.Labels = merge(desired.Spec.Template.Labels, deployment.Spec.Selector.MatchLabels)
+		desired.Annotations[templateHashAnnotation] = hashPodTemplate(&desired.Spec.Template)
+	}
+	templateHash := desired.Annotations[templateHashAnnotation]
+
+	// a template that failed its analysis stays as the analysis left it until
+	// the Robot asks for a different one
+	if analysisFailed(robot, templateHash) {
+		desired.Spec.Template = deployment.Spec.Template
+		desired.Spec.Paused = deployment.Spec.Paused
+	}
+
+	replicasChanged := replicas != nil && (deployment.Spec.Replicas == nil || *replicas != *deployment.Spec.Replicas)
+	templateChanged := deployment.Annotations[templateHashAnnotation] != templateHash
+	if !replicasChanged && !templateChanged && deployment.Spec.Paused == desired.Spec.Paused && !metadataChanged(deployment, robot) {
+		return deployment, nil
+	}
+
+	klog.V(4).Infof("Updating Deployment %s of Robot %s, template hash: %s", deployment.Name, robot.Name, templateHash)
+	return c.kubeClientset.AppsV1().Deployments(robot.Namespace).Update(context.TODO(), desired, c.updateOptions(robot, "Deployment", deployment.Name))
+}
+
+// legacySelector returns the selector Deployments of robot were created with
+// before it derived their labels from robot.
+func legacySelector(robot *robotv1.Robot) *metav1.LabelSelector {
+	return &metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			"app":        "nginx",
+			"controller": robot.Name,
+		},
+	}
+}
+
+// replaceDeployment deletes deployment of robot, orphaning its ReplicaSets
+// so that its pods keep running. Once the deletion goes through, robot is
+// reconciled again and creates its Deployment anew, after which
+// cleanupLegacyReplicaSets removes the orphans.
+func (c *Controller) replaceDeployment(robot *robotv1.Robot, deployment *appsv1.Deployment) (*appsv1.Deployment, error) {
+	if deployment.DeletionTimestamp != nil {
+		return deployment, nil
+	}
+
+	klog.V(4).Infof("Replacing Deployment %s of Robot %s with legacy selector", deployment.Name, robot.Name)
+
+	propagation := metav1.DeletePropagationOrphan
+	options := c.deleteOptions(robot, "Deployment", deployment.Name)
+	options.PropagationPolicy = &propagation
+	options.Preconditions = &metav1.Preconditions{UID: &deployment.UID}
+	err := c.kubeClientset.AppsV1().Deployments(robot.Namespace).Delete(context.TODO(), deployment.Name, options)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	c.recorder.Eventf(robot, corev1.EventTypeNormal, DeploymentReplaced, MessageDeploymentReplaced, deployment.Name)
+
+	return deployment, nil
+}
+
+// cleanupLegacyReplicaSets deletes the ReplicaSets orphaned by
+// replaceDeployment, and with them the pods of robot they still run, once
+// deployment, which replaces them, is rolled out.
+func (c *Controller) cleanupLegacyReplicaSets(robot *robotv1.Robot, deployment *appsv1.Deployment) error {
+	if deployment.DeletionTimestamp != nil || !rolloutComplete(deployment) {
+		return nil
+	}
+
+	replicaSets, err := c.replicaSetsLister.ReplicaSets(robot.Namespace).List(labels.SelectorFromSet(legacySelector(robot).MatchLabels))
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range replicaSets {
+		if metav1.GetControllerOf(rs) != nil {
+			continue
+		}
+
+		klog.V(4).Infof("Deleting legacy ReplicaSet %s of Robot %s", rs.Name, robot.Name)
+		propagation := metav1.DeletePropagationBackground
+		options := c.deleteOptions(robot, "ReplicaSet", rs.Name)
+		options.PropagationPolicy = &propagation
+		err := c.kubeClientset.AppsV1().ReplicaSets(robot.Namespace).Delete(context.TODO(), rs.Name, options)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (c *Controller) handleObject(obj interface{}) {
 	var (
 		object metav1.Object
@@ -286,6 +798,32 @@ func (c *Controller) handleObject(obj interface{}) {
 	}
 }
 
+// enqueueDependents enqueues the Robots whose network rules refer to the
+// Robot obj, and the Robots depending on it.
+func (c *Controller) enqueueDependents(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	robot, ok := obj.(*robotv1.Robot)
+	if !ok {
+		utilruntime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+		return
+	}
+
+	key := robot.Namespace + "/" + robot.Name
+	for _, index := range []string{networkPeerIndex, dependencyIndex} {
+		dependents, err := c.robotsIndexer.ByIndex(index, key)
+		if err != nil {
+			utilruntime.HandleError(err)
+			return
+		}
+		for _, dependent := range dependents {
+			c.enqueueRobot(dependent)
+		}
+	}
+}
+
 // enqueueRobot add the key of the object to workqueue.
 func (c *Controller) enqueueRobot(obj interface{}) {
 	var (
@@ -302,47 +840,99 @@ func (c *Controller) enqueueRobot(obj interface{}) {
 	c.workQueue.Add(key)
 }
 
-func (c *Controller) updateRobotStatus(robot *robotv1.Robot, deployment *appsv1.Deployment) error {
+// updateRobotStatus writes status to robot, unless it is already up to date.
+// The status is about the generation of robot it was built from.
+func (c *Controller) updateRobotStatus(robot *robotv1.Robot, status *robotv1.RobotStatus) error {
+	status.ObservedGeneration = robot.Generation
+	if equality.Semantic.DeepEqual(robot.Status, *status) {
+		return nil
+	}
+
 	// NEVER modify objects from the store. It's a read-only, local cache.
 	robotCopy := robot.DeepCopy()
-	robotCopy.Status.AvailableReplicas = deployment.Status.AvailableReplicas
-	_, err := c.robotClientset.RobotV1().Robots(robot.Namespace).Update(context.TODO(), robotCopy, metav1.UpdateOptions{})
+	robotCopy.Status = *status
+	_, err := c.robotClientset.RobotV1().Robots(robot.Namespace).UpdateStatus(context.TODO(), robotCopy, c.updateOptions(robot, "Robot", robot.Name))
 
 	return err
 }
 
-func newDeployment(robot *robotv1.Robot) *appsv1.Deployment {
-	labels := map[string]string{
-		"app":        "nginx",
-		"controller": robot.Name,
+// newDeployment renders the Deployment of robot. configHash is recorded on the
+// pod template so that changing the referenced configuration rolls the pods.
+// defaultSidecars are the operator-wide sidecars.
+func newDeployment(robot *robotv1.Robot, configHash string, defaultSidecars []corev1.Container) *appsv1.Deployment {
+	image := robot.Spec.Image
+	if image == "" {
+		image = defaultImage
 	}
 
-	return &appsv1.Deployment{
+	var resources corev1.ResourceRequirements
+	if robot.Spec.Resources != nil {
+		resources = *robot.Spec.Resources
+	}
+
+	annotations := objectAnnotations(robot)
+	if configHash != "" {
+		annotations[configHashAnnotation] = configHash
+	}
+	if restartedAt, ok := robot.Annotations[robotv1.RestartedAtAnnotation]; ok {
+		annotations[robotv1.RestartedAtAnnotation] = restartedAt
+	}
+
+	volumes, mounts, envFrom := configVolumes(robot)
+	generatedVolumes, generatedMounts, env := secretVolumes(robot)
+	volumes = append(volumes, generatedVolumes...)
+	mounts = append(mounts, generatedMounts...)
+	claimVolumes, claimMounts := storageVolumes(robot)
+	volumes = append(volumes, claimVolumes...)
+	mounts = append(mounts, claimMounts...)
+
+	template := corev1.PodTemplateSpec{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      robot.Spec.DeploymentName,
-			Namespace: robot.Namespace,
-			OwnerReferences: []metav1.OwnerReference{
-				*metav1.NewControllerRef(robot, robotv1.SchemeGroupVersion.WithKind("Robot")),
-			},
+			Labels:      objectLabels(robot),
+			Annotations: annotations,
+		},
+		Spec: corev1.PodSpec{
+			InitContainers: robot.Spec.InitContainers,
+			Containers: append([]corev1.Container{
+				{
+					Name:         "nginx",
+					Image:        image,
+					Resources:    resources,
+					Env:          env,
+					EnvFrom:      envFrom,
+					VolumeMounts: mounts,
+				},
+			}, sidecarContainers(robot, defaultSidecars)...),
+			Volumes:            volumes,
+			ServiceAccountName: serviceAccountName(robot),
 		},
+	}
+	applyScheduling(robot, &template.Spec)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: objectMeta(robot, robot.Spec.DeploymentName),
 		Spec: appsv1.DeploymentSpec{
 			Replicas: robot.Spec.Replicas,
 			Selector: &metav1.LabelSelector{
-				MatchLabels: labels,
-			},
-			Template: corev1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{
-					Labels: labels,
-				},
-				Spec: corev1.PodSpec{
-					Containers: []corev1.Container{
-						{
-							Name:  "nginx",
-							Image: "nginx:latest",
-						},
-					},
-				},
+				MatchLabels: selectorLabels(robot),
 			},
+			Template: template,
 		},
 	}
+	deployment.Annotations[templateHashAnnotation] = hashPodTemplate(&template)
+
+	return deployment
+}
+
+// hashPodTemplate returns a short, stable hash of template.
+func hashPodTemplate(template *corev1.PodTemplateSpec) string {
+	data, err := json.Marshal(template)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("error hashing pod template: %s", err.Error()))
+	}
+
+	hasher := fnv.New32a()
+	hasher.Write(data)
+
+	return rand.SafeEncodeString(fmt.Sprint(hasher.Sum32()))
 }
@@ -0,0 +1,166 @@
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+const (
+	// dependencyIndex indexes Robots by the namespace/name keys of the
+	// Robots they depend on.
+	dependencyIndex = "dependency"
+
+	WaitingForDependencies    = "WaitingForDependencies"
+	DependencyCycle           = "DependencyCycle"
+	MessageDependencyNotReady = "Waiting for Robot %s to have all its replicas available"
+	MessageDependencyMissing  = "Waiting for Robot %s, which does not exist"
+	MessageDependencyCycle    = "Dependencies form a cycle: %s"
+)
+
+// dependencyNamespace returns the namespace of the Robot dependency of robot
+// refers to.
+func dependencyNamespace(robot *robotv1.Robot, dependency robotv1.RobotReference) string {
+	if dependency.Namespace == "" {
+		return robot.Namespace
+	}
+
+	return dependency.Namespace
+}
+
+// dependencyKey returns the namespace/name key of the Robot dependency of
+// robot refers to.
+func dependencyKey(robot *robotv1.Robot, dependency robotv1.RobotReference) string {
+	return dependencyNamespace(robot, dependency) + "/" + dependency.Name
+}
+
+// indexRobotDependencies is a cache.IndexFunc returning the keys of the
+// Robots a Robot depends on.
+func indexRobotDependencies(obj interface{}) ([]string, error) {
+	robot, ok := obj.(*robotv1.Robot)
+	if !ok {
+		return nil, nil
+	}
+
+	var keys []string
+	for _, dependency := range robot.Spec.DependsOn {
+		keys = append(keys, dependencyKey(robot, dependency))
+	}
+
+	return keys, nil
+}
+
+// syncDependencies reports whether robot has to wait for its dependencies,
+// flagging status with the WaitingForDependencies condition while it does.
+func (c *Controller) syncDependencies(robot *robotv1.Robot, status *robotv1.RobotStatus) (bool, error) {
+	if len(robot.Spec.DependsOn) == 0 {
+		removeCondition(status, robotv1.RobotWaitingForDependencies)
+		return false, nil
+	}
+
+	cycle, err := c.dependencyCycle(robot, []string{robot.Namespace + "/" + robot.Name}, map[string]bool{})
+	if err != nil {
+		return false, err
+	}
+	if cycle != nil {
+		msg := fmt.Sprintf(MessageDependencyCycle, strings.Join(cycle, " -> "))
+		if condition := getCondition(status, robotv1.RobotWaitingForDependencies); condition == nil || condition.Reason != DependencyCycle {
+			c.recorder.Event(robot, corev1.EventTypeWarning, DependencyCycle, msg)
+		}
+		setCondition(status, robotv1.RobotWaitingForDependencies, corev1.ConditionTrue, DependencyCycle, msg)
+		return true, nil
+	}
+
+	for _, dependency := range robot.Spec.DependsOn {
+		key := dependencyKey(robot, dependency)
+		msg, err := c.dependencyNotReady(robot, dependency)
+		if err != nil {
+			return false, err
+		}
+		if msg != "" {
+			setCondition(status, robotv1.RobotWaitingForDependencies, corev1.ConditionTrue, WaitingForDependencies, fmt.Sprintf(msg, key))
+			return true, nil
+		}
+	}
+	removeCondition(status, robotv1.RobotWaitingForDependencies)
+
+	return false, nil
+}
+
+// dependencyNotReady returns the message format explaining why dependency of
+// robot is not ready, "" if it is. A dependency is ready once its Deployment
+// runs at least one replica and has all of them available.
+func (c *Controller) dependencyNotReady(robot *robotv1.Robot, dependency robotv1.RobotReference) (string, error) {
+	namespace := dependencyNamespace(robot, dependency)
+	other, err := c.robotsLister.Robots(namespace).Get(dependency.Name)
+	if errors.IsNotFound(err) {
+		return MessageDependencyMissing, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	deployment, err := c.deploymentsLister.Deployments(namespace).Get(other.Spec.DeploymentName)
+	if errors.IsNotFound(err) {
+		return MessageDependencyNotReady, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if deployment.Status.ObservedGeneration < deployment.Generation ||
+		deployment.Spec.Replicas == nil || *deployment.Spec.Replicas == 0 ||
+		deployment.Status.AvailableReplicas < *deployment.Spec.Replicas {
+		return MessageDependencyNotReady, nil
+	}
+
+	return "", nil
+}
+
+// dependencyCycle returns the keys of the Robots forming a cycle through the
+// dependencies of robot, the last Robot of path, back to one of path, nil if
+// there is none. Robots in visited are known not to lead to a cycle.
+func (c *Controller) dependencyCycle(robot *robotv1.Robot, path []string, visited map[string]bool) ([]string, error) {
+	for _, dependency := range robot.Spec.DependsOn {
+		key := dependencyKey(robot, dependency)
+		for i := range path {
+			if path[i] == key {
+				return append(append([]string{}, path[i:]...), key), nil
+			}
+		}
+		if visited[key] {
+			continue
+		}
+
+		other, err := c.robotsLister.Robots(dependencyNamespace(robot, dependency)).Get(dependency.Name)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		cycle, err := c.dependencyCycle(other, append(path, key), visited)
+		if err != nil || cycle != nil {
+			return cycle, err
+		}
+		visited[key] = true
+	}
+
+	return nil, nil
+}
+
+// holdReplicas returns the replicas of deployment while its Robot waits for
+// its dependencies: scaling down goes ahead, scaling up does not.
+func holdReplicas(replicas *int32, deployment *appsv1.Deployment) *int32 {
+	if replicas == nil || deployment.Spec.Replicas == nil || *replicas <= *deployment.Spec.Replicas {
+		return replicas
+	}
+
+	return deployment.Spec.Replicas
+}
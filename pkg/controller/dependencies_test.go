@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+	robotlisters "robot-operator/pkg/generated/listers/robot/v1"
+)
+
+// newDependentRobot returns the Robot with key namespace/name depending on
+// the Robots with keys dependencies.
+func newDependentRobot(key string, dependencies ...string) *robotv1.Robot {
+	namespace, name, _ := cache.SplitMetaNamespaceKey(key)
+	robot := &robotv1.Robot{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	for _, dependency := range dependencies {
+		parts := strings.Split(dependency, "/")
+		robot.Spec.DependsOn = append(robot.Spec.DependsOn, robotv1.RobotReference{Namespace: parts[0], Name: parts[1]})
+	}
+
+	return robot
+}
+
+func TestDependencyCycle(t *testing.T) {
+	tests := []struct {
+		name   string
+		robots []*robotv1.Robot
+		want   []string
+	}{
+		{
+			name: "no dependencies",
+			robots: []*robotv1.Robot{
+				newDependentRobot("default/a"),
+			},
+		},
+		{
+			name: "chain",
+			robots: []*robotv1.Robot{
+				newDependentRobot("default/a", "default/b"),
+				newDependentRobot("default/b", "other/c"),
+				newDependentRobot("other/c"),
+			},
+		},
+		{
+			name: "diamond",
+			robots: []*robotv1.Robot{
+				newDependentRobot("default/a", "default/b", "default/c"),
+				newDependentRobot("default/b", "default/d"),
+				newDependentRobot("default/c", "default/d"),
+				newDependentRobot("default/d"),
+			},
+		},
+		{
+			name: "missing dependency",
+			robots: []*robotv1.Robot{
+				newDependentRobot("default/a", "default/b"),
+			},
+		},
+		{
+			name: "itself",
+			robots: []*robotv1.Robot{
+				newDependentRobot("default/a", "default/a"),
+			},
+			want: []string{"default/a", "default/a"},
+		},
+		{
+			name: "across namespaces",
+			robots: []*robotv1.Robot{
+				newDependentRobot("default/a", "other/b"),
+				newDependentRobot("other/b", "default/a"),
+			},
+			want: []string{"default/a", "other/b", "default/a"},
+		},
+		{
+			name: "cycle the Robot leads to",
+			robots: []*robotv1.Robot{
+				newDependentRobot("default/a", "default/b"),
+				newDependentRobot("default/b", "default/c"),
+				newDependentRobot("default/c", "default/b"),
+			},
+			want: []string{"default/b", "default/c", "default/b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			for _, robot := range tt.robots {
+				indexer.Add(robot)
+			}
+			c := &Controller{robotsLister: robotlisters.NewRobotLister(indexer)}
+			robot := tt.robots[0]
+
+			got, err := c.dependencyCycle(robot, []string{robot.Namespace + "/" + robot.Name}, map[string]bool{})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+)
+
+const (
+	DryRun        = "DryRun"
+	MessageDryRun = "Would %s %s %q"
+)
+
+// object is a resource events can be recorded about.
+type object interface {
+	metav1.Object
+	runtime.Object
+}
+
+// dryRunner builds the options of the writes of a controller. In dry-run
+// mode every write is sent to the API server as a server-side dry run, which
+// validates it without persisting it, and reported instead.
+type dryRunner struct {
+	dryRun bool
+	events record.EventRecorder
+}
+
+// createOptions returns the options creating the object of kind named name
+// for owner.
+func (d dryRunner) createOptions(owner object, kind, name string) metav1.CreateOptions {
+	return metav1.CreateOptions{DryRun: d.dryRunWrite(owner, "create", kind, name)}
+}
+
+// updateOptions returns the options updating the object of kind named name
+// for owner.
+func (d dryRunner) updateOptions(owner object, kind, name string) metav1.UpdateOptions {
+	return metav1.UpdateOptions{DryRun: d.dryRunWrite(owner, "update", kind, name)}
+}
+
+// deleteOptions returns the options deleting the object of kind named name
+// for owner.
+func (d dryRunner) deleteOptions(owner object, kind, name string) metav1.DeleteOptions {
+	return metav1.DeleteOptions{DryRun: d.dryRunWrite(owner, "delete", kind, name)}
+}
+
+// patchOptions returns the options patching the object of kind named name
+// for owner.
+func (d dryRunner) patchOptions(owner object, kind, name string) metav1.PatchOptions {
+	return metav1.PatchOptions{DryRun: d.dryRunWrite(owner, "patch", kind, name)}
+}
+
+// dryRunWrite reports the write in dry-run mode, and returns the DryRun
+// option of the write.
+func (d dryRunner) dryRunWrite(owner object, verb, kind, name string) []string {
+	if !d.dryRun {
+		return nil
+	}
+
+	klog.Infof("Dry run: would %s %s %s/%s of %s", verb, kind, owner.GetNamespace(), name, owner.GetName())
+	d.events.Eventf(owner, corev1.EventTypeNormal, DryRun, MessageDryRun, verb, kind, name)
+
+	return []string{metav1.DryRunAll}
+}
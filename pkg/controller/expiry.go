@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+const (
+	RobotExpiring        = "Expiring"
+	RobotExpired         = "Expired"
+	MessageRobotExpiring = "Robot expires at %s and will be deleted"
+	MessageRobotExpired  = "Robot expired at %s, deleting it"
+
+	// expiryWarning is how long before a Robot expires it is warned about.
+	expiryWarning = 5 * time.Minute
+)
+
+// expirationTime returns when robot expires, nil if it never does.
+func expirationTime(robot *robotv1.Robot) *metav1.Time {
+	var expiresAt *metav1.Time
+	if ttl := robot.Spec.TTLSecondsAfterCreation; ttl != nil {
+		expiresAt = &metav1.Time{Time: robot.CreationTimestamp.Add(time.Duration(*ttl) * time.Second)}
+	}
+	if robot.Spec.ExpiresAt != nil && (expiresAt == nil || robot.Spec.ExpiresAt.Before(expiresAt)) {
+		expiresAt = robot.Spec.ExpiresAt.DeepCopy()
+	}
+
+	return expiresAt
+}
+
+// syncExpiry deletes robot, and with it the objects it owns, once it expires,
+// reporting whether it did. Otherwise it warns shortly before, records the
+// expiration time in status and returns when it should be checked again.
+func (c *Controller) syncExpiry(robot *robotv1.Robot, status *robotv1.RobotStatus, now time.Time) (bool, time.Duration, error) {
+	expiresAt := expirationTime(robot)
+	status.ExpirationTime = expiresAt
+	if expiresAt == nil {
+		removeCondition(status, robotv1.RobotExpiring)
+		return false, 0, nil
+	}
+
+	when := expiresAt.UTC().Format(time.RFC3339)
+	remaining := expiresAt.Sub(now)
+	if remaining <= 0 {
+		c.recorder.Eventf(robot, corev1.EventTypeWarning, RobotExpired, MessageRobotExpired, when)
+		err := c.robotClientset.RobotV1().Robots(robot.Namespace).Delete(context.TODO(), robot.Name, c.deleteOptions(robot, "Robot", robot.Name))
+		if errors.IsNotFound(err) {
+			err = nil
+		}
+		return true, 0, err
+	}
+
+	if remaining > expiryWarning {
+		removeCondition(status, robotv1.RobotExpiring)
+		return false, remaining - expiryWarning, nil
+	}
+
+	// warn once, when the condition appears
+	if getCondition(status, robotv1.RobotExpiring) == nil {
+		c.recorder.Eventf(robot, corev1.EventTypeWarning, RobotExpiring, MessageRobotExpiring, when)
+	}
+	setCondition(status, robotv1.RobotExpiring, corev1.ConditionTrue, RobotExpiring, fmt.Sprintf(MessageRobotExpiring, when))
+
+	return false, remaining, nil
+}
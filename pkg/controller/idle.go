@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+const (
+	ErrIdleQuery                = "ErrIdleQuery"
+	IdleQueryUnavailable        = "IdleQueryUnavailable"
+	MessageIdleQuery            = "Error querying the activity of the Robot: %s"
+	MessageIdleQueryUnavailable = "No Prometheus endpoint configured, cannot evaluate the idle query, Robot is never idle"
+)
+
+// syncIdle reports whether robot has been idle for its quiet period at now,
+// recording in status when it was last active. Unless it is idle, it also
+// returns when it should be checked again.
+func (c *Controller) syncIdle(robot *robotv1.Robot, status *robotv1.RobotStatus, now time.Time) (bool, time.Duration) {
+	idle := robot.Spec.Idle
+	if idle == nil {
+		status.LastActivityTime = nil
+		removeCondition(status, robotv1.RobotIdleQueryUnavailable)
+		return false, 0
+	}
+
+	// a query that cannot be evaluated never says the Robot is idle
+	unavailable := idle.Query != "" && c.prometheus == nil
+	if !unavailable {
+		removeCondition(status, robotv1.RobotIdleQueryUnavailable)
+	} else if getCondition(status, robotv1.RobotIdleQueryUnavailable) == nil {
+		c.recorder.Event(robot, corev1.EventTypeWarning, IdleQueryUnavailable, MessageIdleQueryUnavailable)
+		setCondition(status, robotv1.RobotIdleQueryUnavailable, corev1.ConditionTrue, IdleQueryUnavailable, MessageIdleQueryUnavailable)
+	}
+
+	lastActivity := robot.CreationTimestamp.Time
+	if status.LastActivityTime != nil {
+		lastActivity = status.LastActivityTime.Time
+	}
+
+	if value, ok := robot.Annotations[robotv1.LastActivityAnnotation]; ok {
+		if t, err := time.Parse(time.RFC3339, value); err == nil && t.After(lastActivity) {
+			lastActivity = t
+		}
+	}
+
+	// an idle Robot has no pods to serve requests: only the annotation
+	// wakes it up
+	condition := getCondition(status, robotv1.RobotSuspended)
+	asleep := condition != nil && condition.Reason == RobotIdle
+
+	quietPeriod := time.Duration(idle.QuietPeriodSeconds) * time.Second
+	if now.Sub(lastActivity) >= quietPeriod && !asleep && idle.Query != "" {
+		if unavailable {
+			lastActivity = now
+		} else {
+			// the query covers the quiet period, so it is only worth asking
+			// once the annotation alone says the Robot is idle
+			ctx, cancel := context.WithTimeout(context.TODO(), queryTimeout)
+			value, err := c.prometheus.Query(ctx, idle.Query)
+			cancel()
+			switch {
+			case err != nil:
+				// never scale a Robot down on a guess
+				c.recorder.Eventf(robot, corev1.EventTypeWarning, ErrIdleQuery, MessageIdleQuery, err.Error())
+				lastActivity = now
+			case value > 0:
+				lastActivity = now
+			}
+		}
+	}
+
+	status.LastActivityTime = &metav1.Time{Time: lastActivity}
+
+	remaining := lastActivity.Add(quietPeriod).Sub(now)
+	if remaining <= 0 {
+		return true, 0
+	}
+
+	return false, remaining
+}
@@ -0,0 +1,100 @@
+package controller
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+// The recommended labels set on the objects owned by a Robot.
+const (
+	nameLabel      = "app.kubernetes.io/name"
+	instanceLabel  = "app.kubernetes.io/instance"
+	managedByLabel = "app.kubernetes.io/managed-by"
+
+	appName = "robot"
+)
+
+// selectorLabels returns the labels selecting the pods of robot.
+func selectorLabels(robot *robotv1.Robot) map[string]string {
+	return map[string]string{
+		nameLabel:     appName,
+		instanceLabel: robot.Name,
+	}
+}
+
+// objectLabels returns the labels of the objects owned by robot, including
+// its pods: its spec.labels and the recommended labels, which win.
+func objectLabels(robot *robotv1.Robot) map[string]string {
+	labels := make(map[string]string, len(robot.Spec.Labels)+3)
+	for k, v := range robot.Spec.Labels {
+		labels[k] = v
+	}
+	for k, v := range selectorLabels(robot) {
+		labels[k] = v
+	}
+	labels[managedByLabel] = controllerAgentName
+
+	return labels
+}
+
+// objectAnnotations returns the annotations of the objects owned by robot.
+func objectAnnotations(robot *robotv1.Robot) map[string]string {
+	annotations := make(map[string]string, len(robot.Spec.Annotations))
+	for k, v := range robot.Spec.Annotations {
+		annotations[k] = v
+	}
+
+	return annotations
+}
+
+// objectMeta returns the metadata of the object named name owned by robot.
+func objectMeta(robot *robotv1.Robot, name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        name,
+		Namespace:   robot.Namespace,
+		Labels:      objectLabels(robot),
+		Annotations: objectAnnotations(robot),
+		OwnerReferences: []metav1.OwnerReference{
+			*metav1.NewControllerRef(robot, robotv1.SchemeGroupVersion.WithKind("Robot")),
+		},
+	}
+}
+
+// metadataChanged reports whether obj lacks any of the labels or annotations
+// robot propagates to the objects it owns.
+func metadataChanged(obj metav1.Object, robot *robotv1.Robot) bool {
+	return !containsAll(obj.GetLabels(), objectLabels(robot)) ||
+		!containsAll(obj.GetAnnotations(), objectAnnotations(robot))
+}
+
+// applyMetadata adds the labels and annotations robot propagates to obj,
+// leaving others in place.
+func applyMetadata(obj metav1.Object, robot *robotv1.Robot) {
+	obj.SetLabels(merge(obj.GetLabels(), objectLabels(robot)))
+	obj.SetAnnotations(merge(obj.GetAnnotations(), objectAnnotations(robot)))
+}
+
+func containsAll(m, subset map[string]string) bool {
+	for k, v := range subset {
+		if value, ok := m[k]; !ok || value != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+func merge(m, from map[string]string) map[string]string {
+	if len(from) == 0 {
+		return m
+	}
+	if m == nil {
+		m = make(map[string]string, len(from))
+	}
+	for k, v := range from {
+		m[k] = v
+	}
+
+	return m
+}
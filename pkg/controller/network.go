@@ -0,0 +1,182 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+const (
+	// networkPeerIndex indexes Robots by the namespace/name keys of the
+	// Robots their network rules refer to.
+	networkPeerIndex = "networkPeer"
+
+	// namespaceNameLabel is set by Kubernetes on every Namespace from 1.21
+	// on, the oldest server version network rules naming namespaces work
+	// with unless the label is set by hand.
+	namespaceNameLabel = "kubernetes.io/metadata.name"
+
+	ErrUnknownPeer     = "ErrUnknownPeer"
+	MessageUnknownPeer = "Network rule refers to Robot %q which does not exist"
+)
+
+// indexRobotNetworkPeers is a cache.IndexFunc returning the keys of the
+// Robots referred to by the network rules of a Robot.
+func indexRobotNetworkPeers(obj interface{}) ([]string, error) {
+	robot, ok := obj.(*robotv1.Robot)
+	if !ok || robot.Spec.Network == nil {
+		return nil, nil
+	}
+
+	var keys []string
+	for _, rules := range [][]robotv1.NetworkRule{robot.Spec.Network.Ingress, robot.Spec.Network.Egress} {
+		for _, rule := range rules {
+			for _, name := range rule.Robots {
+				keys = append(keys, robot.Namespace+"/"+name)
+			}
+		}
+	}
+
+	return keys, nil
+}
+
+// syncNetworkPolicy makes the NetworkPolicy owned by robot match its
+// spec.network, deleting it once spec.network is removed.
+func (c *Controller) syncNetworkPolicy(robot *robotv1.Robot) error {
+	policy, err := c.networkPoliciesLister.NetworkPolicies(robot.Namespace).Get(robot.Name)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	if robot.Spec.Network == nil {
+		if policy != nil && metav1.IsControlledBy(policy, robot) {
+			err = c.kubeClientset.NetworkingV1().NetworkPolicies(robot.Namespace).Delete(context.TODO(), robot.Name, c.deleteOptions(robot, "NetworkPolicy", robot.Name))
+			if err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if policy != nil && !metav1.IsControlledBy(policy, robot) {
+		msg := fmt.Sprintf(MessageResourceExists, robot.Name)
+		c.recorder.Event(robot, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return fmt.Errorf(msg)
+	}
+
+	desired, err := c.newNetworkPolicy(robot)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case policy == nil:
+		_, err = c.kubeClientset.NetworkingV1().NetworkPolicies(robot.Namespace).Create(context.TODO(), desired, c.createOptions(robot, "NetworkPolicy", robot.Name))
+	case !equality.Semantic.DeepEqual(policy.Spec, desired.Spec) || metadataChanged(policy, robot):
+		policyCopy := policy.DeepCopy()
+		policyCopy.Spec = desired.Spec
+		applyMetadata(policyCopy, robot)
+		_, err = c.kubeClientset.NetworkingV1().NetworkPolicies(robot.Namespace).Update(context.TODO(), policyCopy, c.updateOptions(robot, "NetworkPolicy", robot.Name))
+	}
+
+	return err
+}
+
+// newNetworkPolicy renders the NetworkPolicy of robot, resolving the Robots
+// its rules refer to into selectors of their pods.
+func (c *Controller) newNetworkPolicy(robot *robotv1.Robot) (*networkingv1.NetworkPolicy, error) {
+	network := robot.Spec.Network
+
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: objectMeta(robot, robot.Name),
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: selectorLabels(robot)},
+		},
+	}
+
+	if len(network.Ingress) > 0 {
+		policy.Spec.PolicyTypes = append(policy.Spec.PolicyTypes, networkingv1.PolicyTypeIngress)
+		for _, rule := range network.Ingress {
+			peers, err := c.networkPeers(robot, rule)
+			if err != nil {
+				return nil, err
+			}
+			if len(peers) == 0 && hasPeers(rule) {
+				continue
+			}
+			policy.Spec.Ingress = append(policy.Spec.Ingress, networkingv1.NetworkPolicyIngressRule{
+				From:  peers,
+				Ports: rule.Ports,
+			})
+		}
+	}
+
+	if len(network.Egress) > 0 {
+		policy.Spec.PolicyTypes = append(policy.Spec.PolicyTypes, networkingv1.PolicyTypeEgress)
+		for _, rule := range network.Egress {
+			peers, err := c.networkPeers(robot, rule)
+			if err != nil {
+				return nil, err
+			}
+			if len(peers) == 0 && hasPeers(rule) {
+				continue
+			}
+			policy.Spec.Egress = append(policy.Spec.Egress, networkingv1.NetworkPolicyEgressRule{
+				To:    peers,
+				Ports: rule.Ports,
+			})
+		}
+	}
+
+	return policy, nil
+}
+
+// hasPeers reports whether rule restricts its peers. A rule whose peers all
+// failed to resolve must be dropped rather than allow every peer.
+func hasPeers(rule robotv1.NetworkRule) bool {
+	return len(rule.Robots) > 0 || len(rule.Namespaces) > 0 || len(rule.CIDRs) > 0
+}
+
+// networkPeers returns the peers of rule. Robots that do not exist are left
+// out, which denies them, until they are created.
+func (c *Controller) networkPeers(robot *robotv1.Robot, rule robotv1.NetworkRule) ([]networkingv1.NetworkPolicyPeer, error) {
+	var peers []networkingv1.NetworkPolicyPeer
+
+	for _, name := range rule.Robots {
+		peer, err := c.robotsLister.Robots(robot.Namespace).Get(name)
+		if errors.IsNotFound(err) {
+			c.recorder.Eventf(robot, corev1.EventTypeWarning, ErrUnknownPeer, MessageUnknownPeer, name)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			PodSelector: &metav1.LabelSelector{MatchLabels: selectorLabels(peer)},
+		})
+	}
+
+	for _, namespace := range rule.Namespaces {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{namespaceNameLabel: namespace},
+			},
+		})
+	}
+
+	for _, cidr := range rule.CIDRs {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			IPBlock: &networkingv1.IPBlock{CIDR: cidr},
+		})
+	}
+
+	return peers, nil
+}
@@ -0,0 +1,15 @@
+package controller
+
+import (
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+const (
+	RobotPaused        = "Paused"
+	MessageRobotPaused = "Reconciliation is paused, the objects of the Robot are left as they are"
+)
+
+// paused reports whether the reconciliation of robot is paused.
+func paused(robot *robotv1.Robot) bool {
+	return robot.Spec.Paused || robot.Annotations[robotv1.PausedAnnotation] == "true"
+}
@@ -0,0 +1,175 @@
+package controller
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+const (
+	// crashLoopBackOff is the reason a container restarting in a loop waits
+	// for.
+	crashLoopBackOff = "CrashLoopBackOff"
+)
+
+// startingReasons are the reasons a container that is starting normally waits
+// for, which are not failures.
+var startingReasons = map[string]bool{
+	"ContainerCreating": true,
+	"PodInitializing":   true,
+}
+
+// PodSelector returns the selector of the pods of Robots, which the pod
+// informer of the controller should be restricted to.
+func PodSelector() string {
+	selector := labels.SelectorFromSet(labels.Set{
+		nameLabel:      appName,
+		managedByLabel: controllerAgentName,
+	})
+	instance, err := labels.NewRequirement(instanceLabel, selection.Exists, nil)
+	utilruntime.Must(err)
+
+	return selector.Add(*instance).String()
+}
+
+// handlePod enqueues the Robot of the pod obj.
+func (c *Controller) handlePod(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		utilruntime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+		return
+	}
+
+	robot, err := c.robotsLister.Robots(pod.Namespace).Get(pod.Labels[instanceLabel])
+	if err != nil {
+		return
+	}
+
+	c.enqueueRobot(robot)
+}
+
+// podsStatus sums up the pods of robot.
+func (c *Controller) podsStatus(robot *robotv1.Robot) (robotv1.RobotPodsStatus, error) {
+	status := robotv1.RobotPodsStatus{}
+
+	pods, err := c.podsLister.Pods(robot.Namespace).List(labels.SelectorFromSet(selectorLabels(robot)))
+	if err != nil {
+		return status, err
+	}
+
+	images := make(map[robotv1.RobotPodImage]int32)
+	for _, pod := range pods {
+		if podReady(pod) {
+			status.Ready++
+		}
+		if pod.Status.Phase == corev1.PodPending {
+			status.Pending++
+		}
+
+		crashLooping := false
+		containerStatuses := append(append([]corev1.ContainerStatus(nil), pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, container := range containerStatuses {
+			status.Restarts += container.RestartCount
+
+			if container.State.Running != nil {
+				images[robotv1.RobotPodImage{Image: container.Image, ImageID: container.ImageID}]++
+			}
+
+			// the failure of a container waiting to restart is its last
+			// termination, while waiting for anything but a normal start is
+			// a failure of its own
+			if waiting := container.State.Waiting; waiting != nil {
+				if waiting.Reason == crashLoopBackOff {
+					crashLooping = true
+				} else if waiting.Reason != "" && !startingReasons[waiting.Reason] {
+					status.LastFailure = lastFailure(status.LastFailure, &robotv1.RobotPodFailure{
+						Pod:       pod.Name,
+						Container: container.Name,
+						Reason:    waiting.Reason,
+						Message:   waiting.Message,
+						Time:      waitingSince(pod),
+					})
+				}
+			}
+
+			for _, terminated := range []*corev1.ContainerStateTerminated{container.State.Terminated, container.LastTerminationState.Terminated} {
+				if terminated == nil || terminated.ExitCode == 0 {
+					continue
+				}
+				status.LastFailure = lastFailure(status.LastFailure, &robotv1.RobotPodFailure{
+					Pod:       pod.Name,
+					Container: container.Name,
+					ExitCode:  terminated.ExitCode,
+					Reason:    terminated.Reason,
+					Message:   terminated.Message,
+					Time:      terminated.FinishedAt,
+				})
+			}
+		}
+		if crashLooping {
+			status.CrashLooping++
+		}
+	}
+
+	for image, containers := range images {
+		image.Containers = containers
+		status.Images = append(status.Images, image)
+	}
+	sort.Slice(status.Images, func(i, j int) bool {
+		if status.Images[i].Image != status.Images[j].Image {
+			return status.Images[i].Image < status.Images[j].Image
+		}
+		return status.Images[i].ImageID < status.Images[j].ImageID
+	})
+
+	return status, nil
+}
+
+// lastFailure returns the most recent of last and failure, last when they
+// happened at the same time.
+func lastFailure(last, failure *robotv1.RobotPodFailure) *robotv1.RobotPodFailure {
+	if last != nil && !last.Time.Before(&failure.Time) {
+		return last
+	}
+
+	return failure
+}
+
+// waitingSince returns a stable time for the containers of pod that wait:
+// when its containers stopped being ready, or else when it started or was
+// created, as container states do not tell when they began waiting.
+func waitingSince(pod *corev1.Pod) metav1.Time {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.ContainersReady && !condition.LastTransitionTime.IsZero() {
+			return condition.LastTransitionTime
+		}
+	}
+	if pod.Status.StartTime != nil {
+		return *pod.Status.StartTime
+	}
+
+	return pod.CreationTimestamp
+}
+
+// podReady reports whether pod is ready to serve.
+func podReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelister "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+// newRobotPod returns a pod of the Robot named robot, whose containers have
+// statuses and which became unready at unready.
+func newRobotPod(name string, unready time.Time, statuses ...corev1.ContainerStatus) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: metav1.NamespaceDefault,
+			Labels:    map[string]string{nameLabel: appName, instanceLabel: "robot"},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.ContainersReady, Status: corev1.ConditionFalse, LastTransitionTime: metav1.NewTime(unready)},
+			},
+			ContainerStatuses: statuses,
+		},
+	}
+}
+
+func waitingContainer(reason string) corev1.ContainerStatus {
+	return corev1.ContainerStatus{
+		Name:  "nginx",
+		State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason, Message: reason + " message"}},
+	}
+}
+
+func TestPodsStatusLastFailure(t *testing.T) {
+	unready := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	crashed := unready.Add(time.Minute)
+
+	tests := []struct {
+		name string
+		pods []*corev1.Pod
+		want *robotv1.RobotPodFailure
+	}{
+		{
+			name: "starting containers are not failures",
+			pods: []*corev1.Pod{
+				newRobotPod("creating", unready, waitingContainer("ContainerCreating")),
+				newRobotPod("initializing", unready, waitingContainer("PodInitializing")),
+			},
+		},
+		{
+			name: "image pull failure",
+			pods: []*corev1.Pod{newRobotPod("pull", unready, waitingContainer("ImagePullBackOff"))},
+			want: &robotv1.RobotPodFailure{
+				Pod:       "pull",
+				Container: "nginx",
+				Reason:    "ImagePullBackOff",
+				Message:   "ImagePullBackOff message",
+				Time:      metav1.NewTime(unready),
+			},
+		},
+		{
+			name: "crash loop is its last termination",
+			pods: []*corev1.Pod{
+				newRobotPod("crash", unready, corev1.ContainerStatus{
+					Name:  "nginx",
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: crashLoopBackOff}},
+					LastTerminationState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error", FinishedAt: metav1.NewTime(crashed)},
+					},
+				}),
+			},
+			want: &robotv1.RobotPodFailure{
+				Pod:       "crash",
+				Container: "nginx",
+				ExitCode:  1,
+				Reason:    "Error",
+				Time:      metav1.NewTime(crashed),
+			},
+		},
+		{
+			name: "most recent failure wins",
+			pods: []*corev1.Pod{
+				newRobotPod("config", unready.Add(2*time.Minute), waitingContainer("CreateContainerConfigError")),
+				newRobotPod("crash", unready, corev1.ContainerStatus{
+					Name: "nginx",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled", FinishedAt: metav1.NewTime(crashed)},
+					},
+				}),
+			},
+			want: &robotv1.RobotPodFailure{
+				Pod:       "config",
+				Container: "nginx",
+				Reason:    "CreateContainerConfigError",
+				Message:   "CreateContainerConfigError message",
+				Time:      metav1.NewTime(unready.Add(2 * time.Minute)),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			for _, pod := range tt.pods {
+				indexer.Add(pod)
+			}
+			c := &Controller{podsLister: corelister.NewPodLister(indexer)}
+			robot := &robotv1.Robot{ObjectMeta: metav1.ObjectMeta{Name: "robot", Namespace: metav1.NamespaceDefault}}
+
+			status, err := c.podsStatus(robot)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if status.Pending != int32(len(tt.pods)) {
+				t.Errorf("got %d pending pods, want %d", status.Pending, len(tt.pods))
+			}
+			if !reflect.DeepEqual(status.LastFailure, tt.want) {
+				t.Errorf("got %+v, want %+v", status.LastFailure, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+const (
+	// defaultRegistry is the registry of images naming none.
+	defaultRegistry = "docker.io"
+
+	PolicyViolation        = "PolicyViolation"
+	MessagePolicyViolation = "Robot violates its policies: %s"
+)
+
+// handlePolicy enqueues every Robot the RobotPolicy or ClusterRobotPolicy obj
+// applies to.
+func (c *Controller) handlePolicy(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	policy, ok := obj.(metav1.Object)
+	if !ok {
+		utilruntime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+		return
+	}
+
+	var robots []*robotv1.Robot
+	var err error
+	if policy.GetNamespace() == "" {
+		robots, err = c.robotsLister.List(labels.Everything())
+	} else {
+		robots, err = c.robotsLister.Robots(policy.GetNamespace()).List(labels.Everything())
+	}
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+
+	klog.V(4).Infof("Policy '%s' changed, enqueueing %d Robots", policy.GetName(), len(robots))
+	for _, robot := range robots {
+		c.enqueueRobot(robot)
+	}
+}
+
+// policyViolations returns how robot violates the RobotPolicies of its
+// namespace and the ClusterRobotPolicies, each violation naming its policy.
+func (c *Controller) policyViolations(robot *robotv1.Robot) ([]string, error) {
+	policies, err := c.robotPoliciesLister.RobotPolicies(robot.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	clusterPolicies, err := c.clusterRobotPoliciesLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	// report in a stable order, so that conditions do not flap
+	sort.Slice(policies, func(i, j int) bool { return policies[i].Name < policies[j].Name })
+	sort.Slice(clusterPolicies, func(i, j int) bool { return clusterPolicies[i].Name < clusterPolicies[j].Name })
+
+	var violations []string
+	for _, policy := range clusterPolicies {
+		for _, violation := range checkPolicy(robot, &policy.Spec) {
+			violations = append(violations, fmt.Sprintf("ClusterRobotPolicy %q: %s", policy.Name, violation))
+		}
+	}
+	for _, policy := range policies {
+		for _, violation := range checkPolicy(robot, &policy.Spec) {
+			violations = append(violations, fmt.Sprintf("RobotPolicy %q: %s", policy.Name, violation))
+		}
+	}
+
+	return violations, nil
+}
+
+// checkPolicy returns how robot violates policy.
+func checkPolicy(robot *robotv1.Robot, policy *robotv1.RobotPolicySpec) []string {
+	var violations []string
+
+	if maxReplicas := policy.MaxReplicas; maxReplicas != nil {
+		if replicas := robot.Spec.Replicas; replicas != nil && *replicas > *maxReplicas {
+			violations = append(violations, fmt.Sprintf("replicas %d exceed %d", *replicas, *maxReplicas))
+		}
+		for _, schedule := range robot.Spec.Schedules {
+			if replicas := schedule.Replicas; replicas != nil && *replicas > *maxReplicas {
+				violations = append(violations, fmt.Sprintf("replicas %d of schedule %q exceed %d", *replicas, schedule.Name, *maxReplicas))
+			}
+		}
+	}
+
+	for _, key := range policy.RequiredLabels {
+		if _, ok := robot.Labels[key]; !ok {
+			violations = append(violations, fmt.Sprintf("label %q is required", key))
+		}
+	}
+
+	for _, container := range robotContainers(robot) {
+		if len(policy.AllowedRegistries) > 0 && !allowedImage(container.Image, policy.AllowedRegistries) {
+			violations = append(violations, fmt.Sprintf("image %q of container %q is not from an allowed registry", container.Image, container.Name))
+		}
+		if policy.ForbidPrivileged && container.SecurityContext != nil &&
+			container.SecurityContext.Privileged != nil && *container.SecurityContext.Privileged {
+			violations = append(violations, fmt.Sprintf("container %q is privileged", container.Name))
+		}
+		if policy.ForbidHostPorts {
+			for _, port := range container.Ports {
+				if port.HostPort != 0 {
+					violations = append(violations, fmt.Sprintf("container %q binds host port %d", container.Name, port.HostPort))
+				}
+			}
+		}
+		violations = append(violations, resourceViolations(container, policy.MaxResources)...)
+	}
+
+	return violations
+}
+
+// robotContainers returns the containers robot asks for, leaving out the
+// operator-wide sidecars.
+func robotContainers(robot *robotv1.Robot) []corev1.Container {
+	image := robot.Spec.Image
+	if image == "" {
+		image = defaultImage
+	}
+
+	container := corev1.Container{Name: "nginx", Image: image}
+	if robot.Spec.Resources != nil {
+		container.Resources = *robot.Spec.Resources
+	}
+
+	containers := append([]corev1.Container{container}, robot.Spec.Sidecars...)
+	return append(containers, robot.Spec.InitContainers...)
+}
+
+// allowedImage tells whether image comes from one of registries.
+func allowedImage(image string, registries []string) bool {
+	if imageRegistry(image) == "" {
+		image = defaultRegistry + "/" + image
+	}
+
+	for _, registry := range registries {
+		if strings.HasPrefix(image, strings.TrimSuffix(registry, "/")+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resourceViolations returns the requests and limits of container above
+// ceilings.
+func resourceViolations(container corev1.Container, ceilings corev1.ResourceList) []string {
+	var violations []string
+
+	names := make([]string, 0, len(ceilings))
+	for name := range ceilings {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		ceiling := ceilings[corev1.ResourceName(name)]
+		if request, ok := container.Resources.Requests[corev1.ResourceName(name)]; ok && request.Cmp(ceiling) > 0 {
+			violations = append(violations, fmt.Sprintf("%s request %s of container %q exceeds %s", name, request.String(), container.Name, ceiling.String()))
+		}
+		if limit, ok := container.Resources.Limits[corev1.ResourceName(name)]; ok && limit.Cmp(ceiling) > 0 {
+			violations = append(violations, fmt.Sprintf("%s limit %s of container %q exceeds %s", name, limit.String(), container.Name, ceiling.String()))
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+func TestAllowedImage(t *testing.T) {
+	registries := []string{"registry.example.com/bots/", "docker.io/library"}
+
+	tests := []struct {
+		image string
+		want  bool
+	}{
+		{image: "registry.example.com/bots/robot:1", want: true},
+		{image: "registry.example.com/other/robot", want: false},
+		{image: "registry.example.com/botsnet/robot", want: false},
+		{image: "nginx", want: false},
+		{image: "library/nginx", want: true},
+		{image: "docker.io/library/nginx", want: true},
+		{image: "localhost/library/nginx", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			if got := allowedImage(tt.image, registries); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckPolicy(t *testing.T) {
+	privileged := true
+
+	tests := []struct {
+		name   string
+		robot  *robotv1.Robot
+		policy robotv1.RobotPolicySpec
+		want   []string
+	}{
+		{
+			name:   "empty policy",
+			robot:  &robotv1.Robot{Spec: robotv1.RobotSpec{Replicas: int32Ptr(100)}},
+			policy: robotv1.RobotPolicySpec{},
+		},
+		{
+			name: "replicas of the Robot and its schedules",
+			robot: &robotv1.Robot{Spec: robotv1.RobotSpec{
+				Replicas: int32Ptr(5),
+				Schedules: []robotv1.RobotSchedule{
+					{Name: "day", Replicas: int32Ptr(3)},
+					{Name: "peak", Replicas: int32Ptr(10)},
+				},
+			}},
+			policy: robotv1.RobotPolicySpec{MaxReplicas: int32Ptr(3)},
+			want: []string{
+				"replicas 5 exceed 3",
+				`replicas 10 of schedule "peak" exceed 3`,
+			},
+		},
+		{
+			name:   "required labels",
+			robot:  &robotv1.Robot{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"team": "robots"}}},
+			policy: robotv1.RobotPolicySpec{RequiredLabels: []string{"team", "cost-center"}},
+			want:   []string{`label "cost-center" is required`},
+		},
+		{
+			name: "registries of every container",
+			robot: &robotv1.Robot{Spec: robotv1.RobotSpec{
+				Image:          "registry.example.com/robot",
+				Sidecars:       []corev1.Container{{Name: "proxy", Image: "envoy"}},
+				InitContainers: []corev1.Container{{Name: "migrate", Image: "registry.example.com/migrate"}},
+			}},
+			policy: robotv1.RobotPolicySpec{AllowedRegistries: []string{"registry.example.com"}},
+			want:   []string{`image "envoy" of container "proxy" is not from an allowed registry`},
+		},
+		{
+			name:   "default image",
+			robot:  &robotv1.Robot{},
+			policy: robotv1.RobotPolicySpec{AllowedRegistries: []string{"registry.example.com"}},
+			want:   []string{`image "nginx:latest" of container "nginx" is not from an allowed registry`},
+		},
+		{
+			name: "privileged and host ports",
+			robot: &robotv1.Robot{Spec: robotv1.RobotSpec{
+				Sidecars: []corev1.Container{{
+					Name:            "agent",
+					SecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+					Ports:           []corev1.ContainerPort{{ContainerPort: 80}, {ContainerPort: 9100, HostPort: 9100}},
+				}},
+			}},
+			policy: robotv1.RobotPolicySpec{ForbidPrivileged: true, ForbidHostPorts: true},
+			want: []string{
+				`container "agent" is privileged`,
+				`container "agent" binds host port 9100`,
+			},
+		},
+		{
+			name: "resources",
+			robot: &robotv1.Robot{Spec: robotv1.RobotSpec{
+				Resources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+					Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+				},
+			}},
+			policy: robotv1.RobotPolicySpec{MaxResources: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("512Mi"),
+			}},
+			want: []string{
+				`cpu limit 2 of container "nginx" exceeds 1`,
+				`memory limit 1Gi of container "nginx" exceeds 512Mi`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := checkPolicy(tt.robot, &tt.policy)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
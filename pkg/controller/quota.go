@@ -0,0 +1,291 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+const (
+	QuotaExceeded        = "QuotaExceeded"
+	MessageQuotaRobots   = "RobotQuota %q allows %d Robots running replicas"
+	MessageQuotaReplicas = "RobotQuota %q leaves %d replicas"
+	MessageQuotaExceeded = "Robot scaled to %d of %d replicas: %s"
+)
+
+// handleQuota enqueues every Robot in the namespace of the RobotQuota obj.
+func (c *Controller) handleQuota(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	quota, ok := obj.(*robotv1.RobotQuota)
+	if !ok {
+		utilruntime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+		return
+	}
+
+	robots, err := c.robotsLister.Robots(quota.Namespace).List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+
+	klog.V(4).Infof("RobotQuota '%s/%s' changed, enqueueing %d Robots", quota.Namespace, quota.Name, len(robots))
+	for _, robot := range robots {
+		c.enqueueRobot(robot)
+	}
+}
+
+// enqueueQuotaPeers enqueues the Robot obj and every Robot in its namespace if
+// the namespace has a RobotQuota, as the share of each depends on the others.
+func (c *Controller) enqueueQuotaPeers(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	robot, ok := obj.(*robotv1.Robot)
+	if !ok {
+		utilruntime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+		return
+	}
+
+	quotas, err := c.robotQuotasLister.RobotQuotas(robot.Namespace).List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	if len(quotas) == 0 {
+		return
+	}
+
+	peers, err := c.robotsLister.Robots(robot.Namespace).List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+
+	// a deleted Robot is enqueued too, to report the usage it leaves
+	c.enqueueRobot(robot)
+	for _, peer := range peers {
+		c.enqueueRobot(peer)
+	}
+}
+
+// handleQuotaDeployment enqueues the quota peers of the Robot controlling the
+// Deployment obj, as the replicas it runs count against their quotas.
+func (c *Controller) handleQuotaDeployment(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	deployment, ok := obj.(*appsv1.Deployment)
+	if !ok {
+		utilruntime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+		return
+	}
+
+	owner := metav1.GetControllerOf(deployment)
+	if owner == nil || owner.Kind != "Robot" {
+		return
+	}
+	robot, err := c.robotsLister.Robots(deployment.Namespace).Get(owner.Name)
+	if err != nil {
+		return
+	}
+
+	c.enqueueQuotaPeers(robot)
+}
+
+// quotaReplicas returns the replicas robot asks for in its spec, which it
+// counts for against quotas until it has a Deployment.
+func quotaReplicas(robot *robotv1.Robot) int32 {
+	switch {
+	case robot.Spec.Suspend:
+		return 0
+	case robot.Spec.Replicas == nil:
+		return 1
+	default:
+		return *robot.Spec.Replicas
+	}
+}
+
+// quotaUsage returns the replicas each of robots counts for against quotas,
+// by name: the replicas its Deployment runs, which schedules, idleness and
+// quotas decide, or those its spec asks for if it has none yet.
+func (c *Controller) quotaUsage(robots []*robotv1.Robot) (map[string]int32, error) {
+	usage := make(map[string]int32, len(robots))
+	for _, robot := range robots {
+		usage[robot.Name] = quotaReplicas(robot)
+
+		deployment, err := c.deploymentsLister.Deployments(robot.Namespace).Get(robot.Spec.DeploymentName)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !metav1.IsControlledBy(deployment, robot) {
+			continue
+		}
+
+		usage[robot.Name] = 1
+		if deployment.Spec.Replicas != nil {
+			usage[robot.Name] = *deployment.Spec.Replicas
+		}
+	}
+
+	return usage, nil
+}
+
+// syncQuota caps replicas, the replicas the Deployment of robot should run,
+// to what the RobotQuotas of its namespace leave, and reports the usage of
+// the quotas.
+func (c *Controller) syncQuota(robot *robotv1.Robot, status *robotv1.RobotStatus, replicas *int32, deployment *appsv1.Deployment) (*int32, error) {
+	quotas, err := c.robotQuotasLister.RobotQuotas(robot.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	if len(quotas) == 0 {
+		removeCondition(status, robotv1.RobotQuotaExceeded)
+		return replicas, nil
+	}
+
+	robots, err := c.robotsLister.Robots(robot.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	usage, err := c.quotaUsage(robots)
+	if err != nil {
+		return nil, err
+	}
+
+	// the replicas robot asks for, leaving its Deployment as it is if nil
+	want := int32(1)
+	switch {
+	case replicas != nil:
+		want = *replicas
+	case deployment != nil && deployment.Spec.Replicas != nil:
+		want = *deployment.Spec.Replicas
+	}
+
+	sort.Slice(quotas, func(i, j int) bool { return quotas[i].Name < quotas[j].Name })
+	sort.Slice(robots, func(i, j int) bool {
+		if !robots[i].CreationTimestamp.Equal(&robots[j].CreationTimestamp) {
+			return robots[i].CreationTimestamp.Before(&robots[j].CreationTimestamp)
+		}
+		return robots[i].Name < robots[j].Name
+	})
+
+	allowed, reason := want, ""
+	for _, quota := range quotas {
+		if allowance, message := quotaAllowance(quota, robots, usage, robot.Name); allowance < allowed {
+			allowed, reason = allowance, message
+		}
+	}
+
+	// robot is about to run what it is allowed
+	usage[robot.Name] = allowed
+	if err := c.updateQuotaStatuses(quotas, usage); err != nil {
+		return nil, err
+	}
+
+	if allowed == want {
+		removeCondition(status, robotv1.RobotQuotaExceeded)
+		return replicas, nil
+	}
+
+	message := fmt.Sprintf(MessageQuotaExceeded, allowed, want, reason)
+	if condition := getCondition(status, robotv1.RobotQuotaExceeded); condition == nil || condition.Message != message {
+		c.recorder.Event(robot, corev1.EventTypeWarning, QuotaExceeded, message)
+	}
+	setCondition(status, robotv1.RobotQuotaExceeded, corev1.ConditionTrue, QuotaExceeded, message)
+
+	return &allowed, nil
+}
+
+// quotaAllowance returns the replicas quota leaves to the Robot named name
+// out of robots, sorted by creation, each counting for its usage, and why if
+// it leaves a limited number.
+func quotaAllowance(quota *robotv1.RobotQuota, robots []*robotv1.Robot, usage map[string]int32, name string) (int32, string) {
+	remaining := int32(math.MaxInt32)
+	if quota.Spec.MaxReplicas != nil {
+		remaining = *quota.Spec.MaxReplicas
+	}
+
+	for i, robot := range robots {
+		if quota.Spec.MaxRobots != nil && int32(i) >= *quota.Spec.MaxRobots {
+			if robot.Name == name {
+				return 0, fmt.Sprintf(MessageQuotaRobots, quota.Name, *quota.Spec.MaxRobots)
+			}
+			continue
+		}
+		if robot.Name == name {
+			return remaining, fmt.Sprintf(MessageQuotaReplicas, quota.Name, remaining)
+		}
+
+		if replicas := usage[robot.Name]; replicas < remaining {
+			remaining -= replicas
+		} else {
+			remaining = 0
+		}
+	}
+
+	return remaining, fmt.Sprintf(MessageQuotaReplicas, quota.Name, remaining)
+}
+
+// syncQuotaStatus reports the usage of the RobotQuotas of namespace.
+func (c *Controller) syncQuotaStatus(namespace string) error {
+	quotas, err := c.robotQuotasLister.RobotQuotas(namespace).List(labels.Everything())
+	if err != nil || len(quotas) == 0 {
+		return err
+	}
+
+	robots, err := c.robotsLister.Robots(namespace).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	usage, err := c.quotaUsage(robots)
+	if err != nil {
+		return err
+	}
+
+	return c.updateQuotaStatuses(quotas, usage)
+}
+
+// updateQuotaStatuses writes usage, the replicas of each Robot by name, to
+// the status of quotas.
+func (c *Controller) updateQuotaStatuses(quotas []*robotv1.RobotQuota, usage map[string]int32) error {
+	total := robotv1.RobotQuotaStatus{Robots: int32(len(usage))}
+	for _, replicas := range usage {
+		total.Replicas += replicas
+	}
+
+	for _, quota := range quotas {
+		if quota.Status == total {
+			continue
+		}
+
+		// NEVER modify objects from the store. It's a read-only, local cache.
+		quotaCopy := quota.DeepCopy()
+		quotaCopy.Status = total
+		_, err := c.robotClientset.RobotV1().RobotQuotas(quota.Namespace).UpdateStatus(context.TODO(), quotaCopy, c.updateOptions(quota, "RobotQuota", quota.Name))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
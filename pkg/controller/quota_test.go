@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+func int32Ptr(i int32) *int32 { return &i }
+
+// newQuotaRobots returns Robots with names, created in that order.
+func newQuotaRobots(names ...string) []*robotv1.Robot {
+	created := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	robots := make([]*robotv1.Robot, 0, len(names))
+	for i, name := range names {
+		robots = append(robots, &robotv1.Robot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         metav1.NamespaceDefault,
+				CreationTimestamp: metav1.NewTime(created.Add(time.Duration(i) * time.Minute)),
+			},
+		})
+	}
+
+	return robots
+}
+
+func TestQuotaAllowance(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxRobots   *int32
+		maxReplicas *int32
+		usage       map[string]int32
+		robot       string
+		want        int32
+	}{
+		{
+			name:        "first Robot gets the whole quota",
+			maxReplicas: int32Ptr(10),
+			usage:       map[string]int32{"a": 8, "b": 2},
+			robot:       "a",
+			want:        10,
+		},
+		{
+			name:        "later Robot gets what earlier ones leave",
+			maxReplicas: int32Ptr(10),
+			usage:       map[string]int32{"a": 8, "b": 2},
+			robot:       "b",
+			want:        2,
+		},
+		{
+			name:        "earlier Robots count for what they run, not their spec",
+			maxReplicas: int32Ptr(10),
+			usage:       map[string]int32{"a": 8, "b": 0, "c": 4},
+			robot:       "c",
+			want:        2,
+		},
+		{
+			name:        "used up quota leaves nothing",
+			maxReplicas: int32Ptr(5),
+			usage:       map[string]int32{"a": 3, "b": 3, "c": 1},
+			robot:       "c",
+			want:        0,
+		},
+		{
+			name:      "Robots beyond maxRobots run nothing",
+			maxRobots: int32Ptr(2),
+			usage:     map[string]int32{"a": 1, "b": 1, "c": 1},
+			robot:     "c",
+			want:      0,
+		},
+		{
+			name:      "Robots within maxRobots are not capped",
+			maxRobots: int32Ptr(2),
+			usage:     map[string]int32{"a": 1, "b": 1, "c": 1},
+			robot:     "b",
+			want:      math.MaxInt32 - 1,
+		},
+		{
+			name:        "both caps apply",
+			maxRobots:   int32Ptr(2),
+			maxReplicas: int32Ptr(4),
+			usage:       map[string]int32{"a": 3, "b": 3, "c": 1},
+			robot:       "b",
+			want:        1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quota := &robotv1.RobotQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: metav1.NamespaceDefault},
+				Spec:       robotv1.RobotQuotaSpec{MaxRobots: tt.maxRobots, MaxReplicas: tt.maxReplicas},
+			}
+
+			got, _ := quotaAllowance(quota, newQuotaRobots("a", "b", "c"), tt.usage, tt.robot)
+			if got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
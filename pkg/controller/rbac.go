@@ -0,0 +1,284 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+const (
+	RBACRejected        = "RBACRejected"
+	MessageRBACRejected = "Rules exceed the operator's RBAC ceiling: %s"
+)
+
+// serviceAccountName returns the name of the ServiceAccount the pods of robot
+// run as, "" for the namespace default.
+func serviceAccountName(robot *robotv1.Robot) string {
+	sa := robot.Spec.ServiceAccount
+	if sa == nil {
+		return ""
+	}
+	if sa.Name == "" && sa.Create {
+		return robot.Name
+	}
+
+	return sa.Name
+}
+
+// syncServiceAccount creates the ServiceAccount, Role and RoleBinding owned
+// by robot, deleting them once it stops asking for them or names another
+// ServiceAccount. Rules exceeding the
+// operator's ceiling, which are all rules when no ceiling is configured, are
+// refused: the Role is left as it was and status is flagged with the
+// RBACRejected condition.
+func (c *Controller) syncServiceAccount(robot *robotv1.Robot, status *robotv1.RobotStatus) error {
+	sa := robot.Spec.ServiceAccount
+	if sa == nil || !sa.Create {
+		removeCondition(status, robotv1.RobotRBACRejected)
+		return c.deleteServiceAccounts(robot, "")
+	}
+
+	// a renamed ServiceAccount leaves the previous one behind
+	name := serviceAccountName(robot)
+	if err := c.deleteServiceAccounts(robot, name); err != nil {
+		return err
+	}
+
+	serviceAccount, err := c.serviceAccountsLister.ServiceAccounts(robot.Namespace).Get(name)
+	if errors.IsNotFound(err) {
+		serviceAccount, err = c.kubeClientset.CoreV1().ServiceAccounts(robot.Namespace).Create(context.TODO(), newServiceAccount(robot), c.createOptions(robot, "ServiceAccount", name))
+	}
+	if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(serviceAccount, robot) {
+		msg := fmt.Sprintf(MessageResourceExists, name)
+		c.recorder.Event(robot, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return fmt.Errorf(msg)
+	}
+	if metadataChanged(serviceAccount, robot) {
+		serviceAccountCopy := serviceAccount.DeepCopy()
+		applyMetadata(serviceAccountCopy, robot)
+		_, err = c.kubeClientset.CoreV1().ServiceAccounts(robot.Namespace).Update(context.TODO(), serviceAccountCopy, c.updateOptions(robot, "ServiceAccount", name))
+		if err != nil {
+			return err
+		}
+	}
+
+	// without a ceiling no rule is granted
+	var exceeding []string
+	for _, rule := range sa.Rules {
+		exceeding = append(exceeding, exceedCeiling(rule, c.config.RBACCeiling)...)
+	}
+	if len(exceeding) > 0 {
+		msg := fmt.Sprintf(MessageRBACRejected, strings.Join(exceeding, ", "))
+		c.recorder.Event(robot, corev1.EventTypeWarning, RBACRejected, msg)
+		setCondition(status, robotv1.RobotRBACRejected, corev1.ConditionTrue, RBACRejected, msg)
+		return nil
+	}
+	removeCondition(status, robotv1.RobotRBACRejected)
+
+	return c.syncRole(robot, name)
+}
+
+// syncRole makes the Role and RoleBinding named name grant the rules of robot
+// to its ServiceAccount, deleting them when there are no rules.
+func (c *Controller) syncRole(robot *robotv1.Robot, name string) error {
+	role, err := c.rolesLister.Roles(robot.Namespace).Get(name)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	roleBinding, err := c.roleBindingsLister.RoleBindings(robot.Namespace).Get(name)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	if (role != nil && !metav1.IsControlledBy(role, robot)) || (roleBinding != nil && !metav1.IsControlledBy(roleBinding, robot)) {
+		msg := fmt.Sprintf(MessageResourceExists, name)
+		c.recorder.Event(robot, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return fmt.Errorf(msg)
+	}
+
+	rules := robot.Spec.ServiceAccount.Rules
+	if len(rules) == 0 {
+		return c.deleteRole(robot, name)
+	}
+
+	switch {
+	case role == nil:
+		_, err = c.kubeClientset.RbacV1().Roles(robot.Namespace).Create(context.TODO(), newRole(robot, name), c.createOptions(robot, "Role", name))
+	case !reflect.DeepEqual(role.Rules, rules) || metadataChanged(role, robot):
+		roleCopy := role.DeepCopy()
+		roleCopy.Rules = rules
+		applyMetadata(roleCopy, robot)
+		_, err = c.kubeClientset.RbacV1().Roles(robot.Namespace).Update(context.TODO(), roleCopy, c.updateOptions(robot, "Role", name))
+	}
+	if err != nil {
+		return err
+	}
+
+	// the subject and role of a RoleBinding never change
+	switch {
+	case roleBinding == nil:
+		_, err = c.kubeClientset.RbacV1().RoleBindings(robot.Namespace).Create(context.TODO(), newRoleBinding(robot, name), c.createOptions(robot, "RoleBinding", name))
+	case metadataChanged(roleBinding, robot):
+		roleBindingCopy := roleBinding.DeepCopy()
+		applyMetadata(roleBindingCopy, robot)
+		_, err = c.kubeClientset.RbacV1().RoleBindings(robot.Namespace).Update(context.TODO(), roleBindingCopy, c.updateOptions(robot, "RoleBinding", name))
+	}
+
+	return err
+}
+
+// deleteRole deletes the Role and RoleBinding named name owned by robot.
+func (c *Controller) deleteRole(robot *robotv1.Robot, name string) error {
+	role, err := c.rolesLister.Roles(robot.Namespace).Get(name)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	if role != nil && metav1.IsControlledBy(role, robot) {
+		err = c.kubeClientset.RbacV1().Roles(robot.Namespace).Delete(context.TODO(), name, c.deleteOptions(robot, "Role", name))
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	roleBinding, err := c.roleBindingsLister.RoleBindings(robot.Namespace).Get(name)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	if roleBinding != nil && metav1.IsControlledBy(roleBinding, robot) {
+		err = c.kubeClientset.RbacV1().RoleBindings(robot.Namespace).Delete(context.TODO(), name, c.deleteOptions(robot, "RoleBinding", name))
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteServiceAccounts deletes the ServiceAccounts owned by robot but the one
+// named keep, with the Role and RoleBinding of each.
+func (c *Controller) deleteServiceAccounts(robot *robotv1.Robot, keep string) error {
+	serviceAccounts, err := c.serviceAccountsLister.ServiceAccounts(robot.Namespace).List(labels.SelectorFromSet(selectorLabels(robot)))
+	if err != nil {
+		return err
+	}
+
+	for _, serviceAccount := range serviceAccounts {
+		if serviceAccount.Name == keep || !metav1.IsControlledBy(serviceAccount, robot) {
+			continue
+		}
+		if err := c.deleteRole(robot, serviceAccount.Name); err != nil {
+			return err
+		}
+		err = c.kubeClientset.CoreV1().ServiceAccounts(robot.Namespace).Delete(context.TODO(), serviceAccount.Name, c.deleteOptions(robot, "ServiceAccount", serviceAccount.Name))
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// exceedCeiling returns the permissions of rule that no rule of ceiling
+// grants, formatted as "verb group/resource[/name]".
+func exceedCeiling(rule rbacv1.PolicyRule, ceiling []rbacv1.PolicyRule) []string {
+	if len(rule.NonResourceURLs) > 0 {
+		return []string{"nonResourceURLs " + strings.Join(rule.NonResourceURLs, ",")}
+	}
+
+	names := rule.ResourceNames
+	if len(names) == 0 {
+		names = []string{""}
+	}
+
+	var exceeding []string
+	for _, group := range rule.APIGroups {
+		for _, resource := range rule.Resources {
+			for _, verb := range rule.Verbs {
+				for _, name := range names {
+					if ceilingAllows(ceiling, group, resource, verb, name) {
+						continue
+					}
+					permission := fmt.Sprintf("%s %s/%s", verb, group, resource)
+					if name != "" {
+						permission += "/" + name
+					}
+					exceeding = append(exceeding, permission)
+				}
+			}
+		}
+	}
+
+	return exceeding
+}
+
+// ceilingAllows reports whether a rule of ceiling grants verb on the resource
+// of group named name, or on every such resource when name is "".
+func ceilingAllows(ceiling []rbacv1.PolicyRule, group, resource, verb, name string) bool {
+	for _, rule := range ceiling {
+		if !matches(rule.APIGroups, group) || !matches(rule.Resources, resource) || !matches(rule.Verbs, verb) {
+			continue
+		}
+		if len(rule.ResourceNames) == 0 || (name != "" && contains(rule.ResourceNames, name)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matches reports whether values holds value or the "*" wildcard.
+func matches(values []string, value string) bool {
+	return contains(values, rbacv1.ResourceAll) || contains(values, value)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newServiceAccount(robot *robotv1.Robot) *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{
+		ObjectMeta: objectMeta(robot, serviceAccountName(robot)),
+	}
+}
+
+func newRole(robot *robotv1.Robot, name string) *rbacv1.Role {
+	return &rbacv1.Role{
+		ObjectMeta: objectMeta(robot, name),
+		Rules:      robot.Spec.ServiceAccount.Rules,
+	}
+}
+
+func newRoleBinding(robot *robotv1.Robot, name string) *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		ObjectMeta: objectMeta(robot, name),
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      name,
+				Namespace: robot.Namespace,
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     name,
+		},
+	}
+}
@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func TestExceedCeiling(t *testing.T) {
+	ceiling := []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get", "list", "watch"}},
+		{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}, ResourceNames: []string{"robot-token"}},
+		{APIGroups: []string{"batch"}, Resources: []string{"*"}, Verbs: []string{"*"}},
+	}
+
+	tests := []struct {
+		name    string
+		rule    rbacv1.PolicyRule
+		ceiling []rbacv1.PolicyRule
+		want    []string
+	}{
+		{
+			name:    "within the ceiling",
+			rule:    rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get", "watch"}},
+			ceiling: ceiling,
+		},
+		{
+			name:    "verb beyond the ceiling",
+			rule:    rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get", "delete"}},
+			ceiling: ceiling,
+			want:    []string{"delete /configmaps"},
+		},
+		{
+			name:    "wildcards of the ceiling",
+			rule:    rbacv1.PolicyRule{APIGroups: []string{"batch"}, Resources: []string{"jobs", "cronjobs"}, Verbs: []string{"create"}},
+			ceiling: ceiling,
+		},
+		{
+			name:    "named resource the ceiling grants",
+			rule:    rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}, ResourceNames: []string{"robot-token"}},
+			ceiling: ceiling,
+		},
+		{
+			name:    "every resource when the ceiling grants only named ones",
+			rule:    rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}},
+			ceiling: ceiling,
+			want:    []string{"get /secrets"},
+		},
+		{
+			name:    "named resource the ceiling does not grant",
+			rule:    rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}, ResourceNames: []string{"other"}},
+			ceiling: ceiling,
+			want:    []string{"get /secrets/other"},
+		},
+		{
+			name:    "wildcard rule is only granted by a wildcard ceiling",
+			rule:    rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"*"}},
+			ceiling: ceiling,
+			want:    []string{"* /configmaps"},
+		},
+		{
+			name: "non-resource URLs are never granted",
+			rule: rbacv1.PolicyRule{NonResourceURLs: []string{"/metrics"}, Verbs: []string{"get"}},
+			ceiling: []rbacv1.PolicyRule{
+				{NonResourceURLs: []string{"*"}, Verbs: []string{"*"}},
+			},
+			want: []string{"nonResourceURLs /metrics"},
+		},
+		{
+			name: "no ceiling grants nothing",
+			rule: rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get"}},
+			want: []string{"get /configmaps"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := exceedCeiling(tt.rule, tt.ceiling)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,425 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/rand"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	appsinformers "k8s.io/client-go/informers/apps/v1"
+	batchinformers "k8s.io/client-go/informers/batch/v1beta1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	appslister "k8s.io/client-go/listers/apps/v1"
+	batchlister "k8s.io/client-go/listers/batch/v1beta1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+	clientset "robot-operator/pkg/generated/clientset/versioned"
+	robotscheme "robot-operator/pkg/generated/clientset/versioned/scheme"
+	robotinformers "robot-operator/pkg/generated/informers/externalversions/robot/v1"
+	robotlisters "robot-operator/pkg/generated/listers/robot/v1"
+)
+
+const (
+	robotCronTaskControllerAgentName = "robotcrontask-controller"
+
+	// cronTaskLabel marks the pods of a RobotCronTask.
+	cronTaskLabel = "robot.llleon.io/crontask"
+	// cronJobHashAnnotation records on a CronJob the hash of its spec as last
+	// rendered from its RobotCronTask.
+	cronJobHashAnnotation = "robot.llleon.io/cronjob-hash"
+
+	ErrCronTaskSchedule        = "ErrSchedule"
+	MessageCronTaskSchedule    = "Invalid schedule %q: %s"
+	MessageCronJobExists       = "CronJob %q already exists and is not managed by RobotCronTask"
+	MessageRobotCronTaskSynced = "RobotCronTask synced successfully"
+)
+
+// RobotCronTaskController runs RobotCronTasks as CronJobs.
+type RobotCronTaskController struct {
+	kubeClientset  kubernetes.Interface
+	robotClientset clientset.Interface
+
+	deploymentsLister    appslister.DeploymentLister
+	cronJobsLister       batchlister.CronJobLister
+	robotsLister         robotlisters.RobotLister
+	robotCronTasksLister robotlisters.RobotCronTaskLister
+
+	deploymentsSynced    cache.InformerSynced
+	cronJobsSynced       cache.InformerSynced
+	robotsSynced         cache.InformerSynced
+	robotCronTasksSynced cache.InformerSynced
+
+	// dryRunner reports writes instead of making them in dry-run mode.
+	dryRunner
+
+	workQueue workqueue.RateLimitingInterface
+	recorder  record.EventRecorder
+}
+
+func NewRobotCronTaskController(
+	kubeClientset kubernetes.Interface,
+	robotClientset clientset.Interface,
+	deploymentInformer appsinformers.DeploymentInformer,
+	cronJobInformer batchinformers.CronJobInformer,
+	robotInformer robotinformers.RobotInformer,
+	robotCronTaskInformer robotinformers.RobotCronTaskInformer,
+	dryRun bool) *RobotCronTaskController {
+
+	utilruntime.Must(robotscheme.AddToScheme(scheme.Scheme))
+
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartStructuredLogging(0)
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClientset.CoreV1().Events("")})
+	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: robotCronTaskControllerAgentName})
+
+	controller := &RobotCronTaskController{
+		kubeClientset:        kubeClientset,
+		robotClientset:       robotClientset,
+		deploymentsLister:    deploymentInformer.Lister(),
+		cronJobsLister:       cronJobInformer.Lister(),
+		robotsLister:         robotInformer.Lister(),
+		robotCronTasksLister: robotCronTaskInformer.Lister(),
+		deploymentsSynced:    deploymentInformer.Informer().HasSynced,
+		cronJobsSynced:       cronJobInformer.Informer().HasSynced,
+		robotsSynced:         robotInformer.Informer().HasSynced,
+		robotCronTasksSynced: robotCronTaskInformer.Informer().HasSynced,
+		dryRunner:            dryRunner{dryRun: dryRun, events: recorder},
+		workQueue:            workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "RobotCronTasks"),
+		recorder:             recorder,
+	}
+
+	klog.Info("Setting up RobotCronTask event handlers")
+	robotCronTaskInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: controller.enqueueRobotCronTask,
+		UpdateFunc: func(old, new interface{}) {
+			controller.enqueueRobotCronTask(new)
+		},
+	})
+
+	// CronJobs change as they schedule runs
+	cronJobInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: controller.handleCronJob,
+		UpdateFunc: func(old, new interface{}) {
+			if old.(metav1.Object).GetResourceVersion() == new.(metav1.Object).GetResourceVersion() {
+				return
+			}
+
+			controller.handleCronJob(new)
+		},
+		DeleteFunc: controller.handleCronJob,
+	})
+
+	// the pod templates of the Deployments of Robots are rendered into the
+	// CronJobs of their RobotCronTasks
+	deploymentInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: controller.handleDeployment,
+		UpdateFunc: func(old, new interface{}) {
+			oldDepl := old.(*appsv1.Deployment)
+			newDepl := new.(*appsv1.Deployment)
+			if equality.Semantic.DeepEqual(oldDepl.Spec.Template, newDepl.Spec.Template) {
+				return
+			}
+
+			controller.handleDeployment(new)
+		},
+	})
+
+	return controller
+}
+
+func (c *RobotCronTaskController) Run(threadness int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer c.workQueue.ShutDown()
+
+	klog.Info("Starting RobotCronTask controller")
+
+	klog.Info("Waiting for informer caches to sync")
+	if ok := cache.WaitForCacheSync(stopCh, c.deploymentsSynced, c.cronJobsSynced, c.robotsSynced, c.robotCronTasksSynced); !ok {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	klog.Info("Starting RobotCronTask workers")
+	for i := 0; i < threadness; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	klog.Info("Started RobotCronTask workers")
+	<-stopCh
+	klog.Info("Shutting down RobotCronTask workers")
+
+	return nil
+}
+
+func (c *RobotCronTaskController) runWorker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *RobotCronTaskController) processNextWorkItem() bool {
+	obj, shutdown := c.workQueue.Get()
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.workQueue.Done(obj)
+
+		key, ok := obj.(string)
+		if !ok {
+			c.workQueue.Forget(obj)
+			return nil
+		}
+
+		if err := c.reconcile(key); err != nil {
+			c.workQueue.AddRateLimited(key)
+			return fmt.Errorf("error syncing '%s': %s, requeuing", key, err.Error())
+		}
+
+		c.workQueue.Forget(obj)
+		klog.Infof("Successfully synced '%s'", key)
+
+		return nil
+	}(obj)
+
+	if err != nil {
+		utilruntime.HandleError(err)
+	}
+
+	return true
+}
+
+// reconcile creates and updates the CronJob of the RobotCronTask with key
+// from the pod template of the Deployment of its Robot, and reports how the
+// CronJob runs.
+func (c *RobotCronTaskController) reconcile(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("invalid resource key: %s", key))
+		return nil
+	}
+
+	task, err := c.robotCronTasksLister.RobotCronTasks(namespace).Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			utilruntime.HandleError(fmt.Errorf("RobotCronTask '%s' in work queue no longer exists", key))
+			return nil
+		}
+
+		return err
+	}
+
+	// the RobotCronTask stays broken until it is changed
+	if _, err := cron.ParseStandard(task.Spec.Schedule); err != nil {
+		message := fmt.Sprintf(MessageCronTaskSchedule, task.Spec.Schedule, err.Error())
+		c.recorder.Event(task, corev1.EventTypeWarning, ErrCronTaskSchedule, message)
+		status := task.Status.DeepCopy()
+		status.Message = message
+		return c.updateRobotCronTaskStatus(task, status)
+	}
+
+	cronJob, err := c.cronJobsLister.CronJobs(task.Namespace).Get(task.Name)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	if cronJob != nil && !metav1.IsControlledBy(cronJob, task) {
+		msg := fmt.Sprintf(MessageCronJobExists, cronJob.Name)
+		c.recorder.Event(task, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return fmt.Errorf(msg)
+	}
+
+	// a CronJob whose Robot is gone keeps running as it was rendered last
+	template, err := robotPodTemplate(c.robotsLister, c.deploymentsLister, task.Namespace, task.Spec.RobotName)
+	if err != nil {
+		return err
+	}
+	if template == nil && cronJob == nil {
+		status := task.Status.DeepCopy()
+		status.Message = fmt.Sprintf(MessageWaitingForRobot, task.Spec.RobotName)
+		return c.updateRobotCronTaskStatus(task, status)
+	}
+
+	if template != nil {
+		desired := newTaskCronJob(task, template)
+		switch {
+		case cronJob == nil:
+			cronJob, err = c.kubeClientset.BatchV1beta1().CronJobs(task.Namespace).Create(context.TODO(), desired, c.createOptions(task, "CronJob", task.Name))
+		case cronJob.Annotations[cronJobHashAnnotation] != desired.Annotations[cronJobHashAnnotation]:
+			klog.V(4).Infof("Updating CronJob %s of RobotCronTask %s", cronJob.Name, task.Name)
+			cronJobCopy := cronJob.DeepCopy()
+			cronJobCopy.Labels = merge(cronJobCopy.Labels, desired.Labels)
+			cronJobCopy.Annotations = merge(cronJobCopy.Annotations, desired.Annotations)
+			cronJobCopy.Spec = desired.Spec
+			cronJob, err = c.kubeClientset.BatchV1beta1().CronJobs(task.Namespace).Update(context.TODO(), cronJobCopy, c.updateOptions(task, "CronJob", task.Name))
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	status := &robotv1.RobotCronTaskStatus{
+		CronJobName:      cronJob.Name,
+		LastScheduleTime: cronJob.Status.LastScheduleTime,
+	}
+	for _, active := range cronJob.Status.Active {
+		status.Active = append(status.Active, active.Name)
+	}
+	if template == nil {
+		status.Message = fmt.Sprintf(MessageWaitingForRobot, task.Spec.RobotName)
+	}
+	if err := c.updateRobotCronTaskStatus(task, status); err != nil {
+		return err
+	}
+
+	c.recorder.Event(task, corev1.EventTypeNormal, SuccessSynced, MessageRobotCronTaskSynced)
+
+	return nil
+}
+
+// newTaskCronJob renders the CronJob of task from template, the pod template
+// of the Deployment of its Robot.
+func newTaskCronJob(task *robotv1.RobotCronTask, template *corev1.PodTemplateSpec) *batchv1beta1.CronJob {
+	podTemplate := taskPodTemplate(template, map[string]string{cronTaskLabel: task.Name}, task.Spec.Command, task.Spec.Args)
+
+	suspend := task.Spec.Suspend
+	cronJob := &batchv1beta1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        task.Name,
+			Namespace:   task.Namespace,
+			Labels:      podTemplate.Labels,
+			Annotations: map[string]string{},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(task, robotv1.SchemeGroupVersion.WithKind("RobotCronTask")),
+			},
+		},
+		Spec: batchv1beta1.CronJobSpec{
+			Schedule:                   task.Spec.Schedule,
+			StartingDeadlineSeconds:    task.Spec.StartingDeadlineSeconds,
+			ConcurrencyPolicy:          task.Spec.ConcurrencyPolicy,
+			Suspend:                    &suspend,
+			SuccessfulJobsHistoryLimit: task.Spec.SuccessfulJobsHistoryLimit,
+			FailedJobsHistoryLimit:     task.Spec.FailedJobsHistoryLimit,
+			JobTemplate: batchv1beta1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: podTemplate.Labels,
+				},
+				Spec: batchv1.JobSpec{
+					BackoffLimit:          task.Spec.BackoffLimit,
+					ActiveDeadlineSeconds: task.Spec.ActiveDeadlineSeconds,
+					Template:              podTemplate,
+				},
+			},
+		},
+	}
+	cronJob.Annotations[cronJobHashAnnotation] = hashCronJobSpec(&cronJob.Spec)
+
+	return cronJob
+}
+
+// hashCronJobSpec returns a hash of spec. CronJobs are compared by the hash of
+// the spec they were rendered with, as the API server defaults their specs.
+func hashCronJobSpec(spec *batchv1beta1.CronJobSpec) string {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("error hashing CronJob spec: %s", err.Error()))
+	}
+
+	hasher := fnv.New32a()
+	hasher.Write(data)
+
+	return rand.SafeEncodeString(fmt.Sprint(hasher.Sum32()))
+}
+
+// updateRobotCronTaskStatus writes status to task, unless it is already up to
+// date.
+func (c *RobotCronTaskController) updateRobotCronTaskStatus(task *robotv1.RobotCronTask, status *robotv1.RobotCronTaskStatus) error {
+	if equality.Semantic.DeepEqual(task.Status, *status) {
+		return nil
+	}
+
+	taskCopy := task.DeepCopy()
+	taskCopy.Status = *status
+	_, err := c.robotClientset.RobotV1().RobotCronTasks(task.Namespace).UpdateStatus(context.TODO(), taskCopy, c.updateOptions(task, "RobotCronTask", task.Name))
+
+	return err
+}
+
+// handleCronJob enqueues the RobotCronTask controlling the CronJob obj.
+func (c *RobotCronTaskController) handleCronJob(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	cronJob, ok := obj.(*batchv1beta1.CronJob)
+	if !ok {
+		utilruntime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+		return
+	}
+
+	ownerRef := metav1.GetControllerOf(cronJob)
+	if ownerRef == nil || ownerRef.Kind != "RobotCronTask" {
+		return
+	}
+
+	task, err := c.robotCronTasksLister.RobotCronTasks(cronJob.Namespace).Get(ownerRef.Name)
+	if err != nil {
+		klog.V(4).Infof("ignoring orphaned CronJob '%s' of RobotCronTask '%s'", cronJob.Name, ownerRef.Name)
+		return
+	}
+
+	c.enqueueRobotCronTask(task)
+}
+
+// handleDeployment enqueues the RobotCronTasks of the Robot controlling the
+// Deployment obj.
+func (c *RobotCronTaskController) handleDeployment(obj interface{}) {
+	deployment, ok := obj.(*appsv1.Deployment)
+	if !ok {
+		utilruntime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+		return
+	}
+
+	ownerRef := metav1.GetControllerOf(deployment)
+	if ownerRef == nil || ownerRef.Kind != "Robot" {
+		return
+	}
+
+	tasks, err := c.robotCronTasksLister.RobotCronTasks(deployment.Namespace).List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	for _, task := range tasks {
+		if task.Spec.RobotName == ownerRef.Name {
+			c.enqueueRobotCronTask(task)
+		}
+	}
+}
+
+func (c *RobotCronTaskController) enqueueRobotCronTask(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+
+	c.workQueue.Add(key)
+}
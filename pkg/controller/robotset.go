@@ -0,0 +1,596 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/rand"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	appsinformers "k8s.io/client-go/informers/apps/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	appslister "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+	clientset "robot-operator/pkg/generated/clientset/versioned"
+	robotscheme "robot-operator/pkg/generated/clientset/versioned/scheme"
+	robotinformers "robot-operator/pkg/generated/informers/externalversions/robot/v1"
+	robotlisters "robot-operator/pkg/generated/listers/robot/v1"
+)
+
+const (
+	robotSetControllerAgentName = "robotset-controller"
+
+	// robotSetLabel marks the Robots of a RobotSet.
+	robotSetLabel = "robot.llleon.io/robotset"
+	// robotSetHashAnnotation records on a Robot the hash of the template
+	// last rendered for it from its RobotSet.
+	robotSetHashAnnotation = "robot.llleon.io/robotset-hash"
+
+	ErrRobotTemplate         = "ErrRobotTemplate"
+	MessageRobotTemplate     = "Error rendering Robot template: %s"
+	MessageRobotExists       = "Robot %q already exists and is not managed by RobotSet"
+	MessageRobotSetSynced    = "RobotSet synced successfully"
+	defaultMaxUpdatingRobots = 1
+)
+
+// RobotSetController stamps out the Robots of RobotSets.
+type RobotSetController struct {
+	kubeClientset  kubernetes.Interface
+	robotClientset clientset.Interface
+
+	deploymentsLister appslister.DeploymentLister
+	robotsLister      robotlisters.RobotLister
+	robotSetsLister   robotlisters.RobotSetLister
+
+	deploymentsSynced cache.InformerSynced
+	robotsSynced      cache.InformerSynced
+	robotSetsSynced   cache.InformerSynced
+
+	// dryRunner reports writes instead of making them in dry-run mode.
+	dryRunner
+
+	workQueue workqueue.RateLimitingInterface
+	recorder  record.EventRecorder
+}
+
+func NewRobotSetController(
+	kubeClientset kubernetes.Interface,
+	robotClientset clientset.Interface,
+	deploymentInformer appsinformers.DeploymentInformer,
+	robotInformer robotinformers.RobotInformer,
+	robotSetInformer robotinformers.RobotSetInformer,
+	dryRun bool) *RobotSetController {
+
+	utilruntime.Must(robotscheme.AddToScheme(scheme.Scheme))
+
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartStructuredLogging(0)
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClientset.CoreV1().Events("")})
+	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: robotSetControllerAgentName})
+
+	controller := &RobotSetController{
+		kubeClientset:     kubeClientset,
+		robotClientset:    robotClientset,
+		deploymentsLister: deploymentInformer.Lister(),
+		robotsLister:      robotInformer.Lister(),
+		robotSetsLister:   robotSetInformer.Lister(),
+		deploymentsSynced: deploymentInformer.Informer().HasSynced,
+		robotsSynced:      robotInformer.Informer().HasSynced,
+		robotSetsSynced:   robotSetInformer.Informer().HasSynced,
+		dryRunner:         dryRunner{dryRun: dryRun, events: recorder},
+		workQueue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "RobotSets"),
+		recorder:          recorder,
+	}
+
+	klog.Info("Setting up RobotSet event handlers")
+	robotSetInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: controller.enqueueRobotSet,
+		UpdateFunc: func(old, new interface{}) {
+			controller.enqueueRobotSet(new)
+		},
+	})
+
+	// Robots change as they are reconciled, and so does their readiness
+	robotInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: controller.handleRobot,
+		UpdateFunc: func(old, new interface{}) {
+			if old.(metav1.Object).GetResourceVersion() == new.(metav1.Object).GetResourceVersion() {
+				return
+			}
+
+			controller.handleRobot(new)
+		},
+		DeleteFunc: controller.handleRobot,
+	})
+
+	// so do Deployments as they roll out
+	deploymentInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, new interface{}) {
+			if old.(metav1.Object).GetResourceVersion() == new.(metav1.Object).GetResourceVersion() {
+				return
+			}
+
+			controller.handleDeployment(new)
+		},
+	})
+
+	return controller
+}
+
+func (c *RobotSetController) Run(threadness int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer c.workQueue.ShutDown()
+
+	klog.Info("Starting RobotSet controller")
+
+	klog.Info("Waiting for informer caches to sync")
+	if ok := cache.WaitForCacheSync(stopCh, c.deploymentsSynced, c.robotsSynced, c.robotSetsSynced); !ok {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	klog.Info("Starting RobotSet workers")
+	for i := 0; i < threadness; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	klog.Info("Started RobotSet workers")
+	<-stopCh
+	klog.Info("Shutting down RobotSet workers")
+
+	return nil
+}
+
+func (c *RobotSetController) runWorker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *RobotSetController) processNextWorkItem() bool {
+	obj, shutdown := c.workQueue.Get()
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.workQueue.Done(obj)
+
+		key, ok := obj.(string)
+		if !ok {
+			c.workQueue.Forget(obj)
+			return nil
+		}
+
+		if err := c.reconcile(key); err != nil {
+			c.workQueue.AddRateLimited(key)
+			return fmt.Errorf("error syncing '%s': %s, requeuing", key, err.Error())
+		}
+
+		c.workQueue.Forget(obj)
+		klog.Infof("Successfully synced '%s'", key)
+
+		return nil
+	}(obj)
+
+	if err != nil {
+		utilruntime.HandleError(err)
+	}
+
+	return true
+}
+
+// reconcile creates, updates and prunes the Robots of the RobotSet with key,
+// creating or updating at most spec.updateStrategy.maxUpdating Robots at once.
+func (c *RobotSetController) reconcile(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("invalid resource key: %s", key))
+		return nil
+	}
+
+	set, err := c.robotSetsLister.RobotSets(namespace).Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			utilruntime.HandleError(fmt.Errorf("RobotSet '%s' in work queue no longer exists", key))
+			return nil
+		}
+
+		return err
+	}
+
+	desired, err := renderRobots(set)
+	if err != nil {
+		// the RobotSet stays broken until it is changed
+		c.recorder.Eventf(set, corev1.EventTypeWarning, ErrRobotTemplate, MessageRobotTemplate, err.Error())
+		return nil
+	}
+
+	existing, err := c.robotsLister.Robots(set.Namespace).List(labels.SelectorFromSet(labels.Set{robotSetLabel: set.Name}))
+	if err != nil {
+		return err
+	}
+	owned := make(map[string]*robotv1.Robot, len(existing))
+	for _, robot := range existing {
+		if metav1.IsControlledBy(robot, set) {
+			owned[robot.Name] = robot
+		}
+	}
+
+	// prune the Robots no longer part of the set
+	for robotName := range owned {
+		if _, ok := desired[robotName]; ok {
+			continue
+		}
+		err = c.robotClientset.RobotV1().Robots(set.Namespace).Delete(context.TODO(), robotName, c.deleteOptions(set, "Robot", robotName))
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		delete(owned, robotName)
+	}
+
+	// Robots being updated count against the budget until they are ready
+	var outdated []string
+	status := robotv1.RobotSetStatus{}
+	updating := 0
+	for robotName, robot := range desired {
+		current, ok := owned[robotName]
+		if !ok {
+			continue
+		}
+		if robotOutdated(current, robot) {
+			outdated = append(outdated, robotName)
+			continue
+		}
+
+		ready, err := c.robotReady(current)
+		if err != nil {
+			return err
+		}
+		if !ready {
+			updating++
+		}
+	}
+
+	maxUpdating := int(set.Spec.UpdateStrategy.MaxUpdating)
+	if maxUpdating <= 0 {
+		maxUpdating = defaultMaxUpdatingRobots
+	}
+
+	// create the missing Robots, in name order, within the budget
+	var missing []string
+	for robotName := range desired {
+		if _, ok := owned[robotName]; !ok {
+			missing = append(missing, robotName)
+		}
+	}
+	sort.Strings(missing)
+	for _, robotName := range missing {
+		if updating >= maxUpdating {
+			break
+		}
+		robot := desired[robotName]
+
+		current, err := c.robotsLister.Robots(set.Namespace).Get(robotName)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		if current != nil {
+			msg := fmt.Sprintf(MessageRobotExists, robotName)
+			c.recorder.Event(set, corev1.EventTypeWarning, ErrResourceExists, msg)
+			return fmt.Errorf(msg)
+		}
+
+		created, err := c.robotClientset.RobotV1().Robots(set.Namespace).Create(context.TODO(), robot, c.createOptions(set, "Robot", robotName))
+		if err != nil {
+			return err
+		}
+		owned[robotName] = created
+		updating++
+	}
+
+	// update the outdated Robots, in name order, within the budget
+	sort.Strings(outdated)
+	for _, robotName := range outdated {
+		if updating >= maxUpdating {
+			break
+		}
+
+		robot := desired[robotName]
+		robotCopy := owned[robotName].DeepCopy()
+		robotCopy.Labels = merge(robotCopy.Labels, robot.Labels)
+		robotCopy.Annotations = merge(robotCopy.Annotations, robot.Annotations)
+		robotCopy.Spec = robot.Spec
+		updated, err := c.robotClientset.RobotV1().Robots(set.Namespace).Update(context.TODO(), robotCopy, c.updateOptions(set, "Robot", robotName))
+		if err != nil {
+			return err
+		}
+		owned[robotName] = updated
+		updating++
+	}
+
+	for robotName, robot := range owned {
+		status.Robots++
+		if !robotOutdated(robot, desired[robotName]) {
+			status.UpdatedRobots++
+		}
+		ready, err := c.robotReady(robot)
+		if err != nil {
+			return err
+		}
+		if ready {
+			status.ReadyRobots++
+		}
+		status.AvailableReplicas += robot.Status.AvailableReplicas
+	}
+
+	if err := c.updateRobotSetStatus(set, &status); err != nil {
+		return err
+	}
+
+	c.recorder.Event(set, corev1.EventTypeNormal, SuccessSynced, MessageRobotSetSynced)
+
+	return nil
+}
+
+// robotOutdated reports whether current differs from robot, as rendered
+// from the template of its RobotSet, either because the template changed or
+// because current was edited since.
+func robotOutdated(current, robot *robotv1.Robot) bool {
+	return current.Annotations[robotSetHashAnnotation] != robot.Annotations[robotSetHashAnnotation] ||
+		!equality.Semantic.DeepEqual(current.Spec, robot.Spec)
+}
+
+// robotReady reports whether robot was reconciled since it last changed, and
+// its Deployment is rolled out and passed its analysis.
+func (c *RobotSetController) robotReady(robot *robotv1.Robot) (bool, error) {
+	if robot.Status.ObservedGeneration < robot.Generation {
+		return false, nil
+	}
+	if analysis := robot.Status.Analysis; analysis != nil && analysis.Phase != robotv1.AnalysisPhaseSuccessful {
+		return false, nil
+	}
+
+	deployment, err := c.deploymentsLister.Deployments(robot.Namespace).Get(robot.Spec.DeploymentName)
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return metav1.IsControlledBy(deployment, robot) && rolloutComplete(deployment), nil
+}
+
+// updateRobotSetStatus writes status to set, unless it is already up to date.
+func (c *RobotSetController) updateRobotSetStatus(set *robotv1.RobotSet, status *robotv1.RobotSetStatus) error {
+	if equality.Semantic.DeepEqual(set.Status, *status) {
+		return nil
+	}
+
+	setCopy := set.DeepCopy()
+	setCopy.Status = *status
+	_, err := c.robotClientset.RobotV1().RobotSets(set.Namespace).UpdateStatus(context.TODO(), setCopy, c.updateOptions(set, "RobotSet", set.Name))
+
+	return err
+}
+
+// handleRobot enqueues the RobotSet controlling the Robot obj.
+func (c *RobotSetController) handleRobot(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	robot, ok := obj.(*robotv1.Robot)
+	if !ok {
+		utilruntime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+		return
+	}
+
+	ownerRef := metav1.GetControllerOf(robot)
+	if ownerRef == nil || ownerRef.Kind != "RobotSet" {
+		return
+	}
+
+	set, err := c.robotSetsLister.RobotSets(robot.Namespace).Get(ownerRef.Name)
+	if err != nil {
+		klog.V(4).Infof("ignoring orphaned Robot '%s' of RobotSet '%s'", robot.Name, ownerRef.Name)
+		return
+	}
+
+	c.enqueueRobotSet(set)
+}
+
+// handleDeployment enqueues the RobotSet controlling the Robot controlling
+// the Deployment obj.
+func (c *RobotSetController) handleDeployment(obj interface{}) {
+	deployment, ok := obj.(*appsv1.Deployment)
+	if !ok {
+		utilruntime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+		return
+	}
+
+	ownerRef := metav1.GetControllerOf(deployment)
+	if ownerRef == nil || ownerRef.Kind != "Robot" {
+		return
+	}
+
+	robot, err := c.robotsLister.Robots(deployment.Namespace).Get(ownerRef.Name)
+	if err != nil {
+		return
+	}
+
+	c.handleRobot(robot)
+}
+
+// enqueueRobotSet adds the key of the RobotSet obj to the work queue.
+func (c *RobotSetController) enqueueRobotSet(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+
+	c.workQueue.Add(key)
+}
+
+// robotSetParameters returns the parameters of set, generated ones included.
+func robotSetParameters(set *robotv1.RobotSet) ([]robotv1.RobotSetParameter, error) {
+	parameters := append([]robotv1.RobotSetParameter{}, set.Spec.Parameters...)
+	if generator := set.Spec.Generator; generator != nil {
+		for i := 0; i < int(generator.Count); i++ {
+			index := strconv.Itoa(i)
+			parameters = append(parameters, robotv1.RobotSetParameter{
+				Name:   index,
+				Values: map[string]string{"index": index},
+			})
+		}
+	}
+
+	seen := make(map[string]bool, len(parameters))
+	for _, parameter := range parameters {
+		if parameter.Name == "" {
+			return nil, fmt.Errorf("parameter without a name")
+		}
+		if seen[parameter.Name] {
+			return nil, fmt.Errorf("duplicate parameter %q", parameter.Name)
+		}
+		seen[parameter.Name] = true
+	}
+
+	return parameters, nil
+}
+
+// renderRobots returns the Robots of set by name.
+func renderRobots(set *robotv1.RobotSet) (map[string]*robotv1.Robot, error) {
+	parameters, err := robotSetParameters(set)
+	if err != nil {
+		return nil, err
+	}
+
+	robots := make(map[string]*robotv1.Robot, len(parameters))
+	for _, parameter := range parameters {
+		robot, err := renderRobot(set, parameter)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q: %s", parameter.Name, err.Error())
+		}
+		robots[robot.Name] = robot
+	}
+
+	return robots, nil
+}
+
+// renderRobot returns the Robot of set for parameter, executing the string
+// values of the template of set.
+func renderRobot(set *robotv1.RobotSet, parameter robotv1.RobotSetParameter) (*robotv1.Robot, error) {
+	name := set.Name + "-" + parameter.Name
+	values := struct {
+		Name      string
+		Namespace string
+		Parameter robotv1.RobotSetParameter
+	}{name, set.Namespace, parameter}
+
+	data, err := json.Marshal(set.Spec.Template)
+	if err != nil {
+		return nil, err
+	}
+	var tree interface{}
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return nil, err
+	}
+	if tree, err = renderStrings(tree, values); err != nil {
+		return nil, err
+	}
+	if data, err = json.Marshal(tree); err != nil {
+		return nil, err
+	}
+	var rendered robotv1.RobotTemplate
+	if err := json.Unmarshal(data, &rendered); err != nil {
+		return nil, err
+	}
+
+	if rendered.Spec.DeploymentName == "" {
+		rendered.Spec.DeploymentName = name
+	}
+
+	// json.Marshal sorts map keys, which keeps the hash stable
+	hashed, err := json.Marshal(rendered)
+	if err != nil {
+		return nil, err
+	}
+	hasher := fnv.New32a()
+	hasher.Write(hashed)
+
+	return &robotv1.Robot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: set.Namespace,
+			Labels:    merge(rendered.Labels, map[string]string{robotSetLabel: set.Name}),
+			Annotations: merge(rendered.Annotations, map[string]string{
+				robotSetHashAnnotation: rand.SafeEncodeString(fmt.Sprint(hasher.Sum32())),
+			}),
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(set, robotv1.SchemeGroupVersion.WithKind("RobotSet")),
+			},
+		},
+		Spec: rendered.Spec,
+	}, nil
+}
+
+// renderStrings executes the strings of the decoded JSON value as templates
+// with values.
+func renderStrings(value interface{}, values interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			rendered, err := renderStrings(item, values)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = rendered
+		}
+	case []interface{}:
+		for i, item := range v {
+			rendered, err := renderStrings(item, values)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = rendered
+		}
+	case string:
+		if !strings.Contains(v, "{{") {
+			return v, nil
+		}
+
+		var buf bytes.Buffer
+		tmpl, err := template.New("").Option("missingkey=error").Parse(v)
+		if err == nil {
+			err = tmpl.Execute(&buf, values)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return buf.String(), nil
+	}
+
+	return value, nil
+}
@@ -0,0 +1,166 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+func newRobotSet(image string, parameters ...robotv1.RobotSetParameter) *robotv1.RobotSet {
+	return &robotv1.RobotSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "fleet", Namespace: metav1.NamespaceDefault},
+		Spec: robotv1.RobotSetSpec{
+			Template: robotv1.RobotTemplate{
+				Labels: map[string]string{"region": "{{ .Parameter.Values.region }}"},
+				Spec:   robotv1.RobotSpec{Image: image},
+			},
+			Parameters: parameters,
+		},
+	}
+}
+
+func TestRenderRobots(t *testing.T) {
+	eu := robotv1.RobotSetParameter{Name: "eu", Values: map[string]string{"region": "eu-west-1"}}
+	us := robotv1.RobotSetParameter{Name: "us", Values: map[string]string{"region": "us-east-1"}}
+
+	tests := []struct {
+		name    string
+		set     *robotv1.RobotSet
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "parameters",
+			set:  newRobotSet("robot:{{ .Parameter.Name }}", eu, us),
+			want: map[string]string{
+				"fleet-eu": "robot:eu eu-west-1",
+				"fleet-us": "robot:us us-east-1",
+			},
+		},
+		{
+			name: "generated parameters",
+			set: func() *robotv1.RobotSet {
+				set := newRobotSet("robot:{{ .Name }}", eu)
+				set.Spec.Template.Labels = nil
+				set.Spec.Generator = &robotv1.RobotSetGenerator{Count: 2}
+				return set
+			}(),
+			want: map[string]string{
+				"fleet-eu": "robot:fleet-eu ",
+				"fleet-0":  "robot:fleet-0 ",
+				"fleet-1":  "robot:fleet-1 ",
+			},
+		},
+		{
+			name:    "duplicate parameter",
+			set:     newRobotSet("robot", eu, eu),
+			wantErr: true,
+		},
+		{
+			name:    "parameter without a name",
+			set:     newRobotSet("robot", robotv1.RobotSetParameter{}),
+			wantErr: true,
+		},
+		{
+			name:    "missing value",
+			set:     newRobotSet("robot", robotv1.RobotSetParameter{Name: "eu"}),
+			wantErr: true,
+		},
+		{
+			name:    "invalid template",
+			set:     newRobotSet("robot:{{ .Parameter.Name", eu),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			robots, err := renderRobots(tt.set)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %d Robots", len(robots))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			got := make(map[string]string, len(robots))
+			for name, robot := range robots {
+				got[name] = robot.Spec.Image + " " + robot.Labels["region"]
+				if robot.Spec.DeploymentName != name {
+					t.Errorf("Robot %q: got deployment name %q", name, robot.Spec.DeploymentName)
+				}
+				if robot.Labels[robotSetLabel] != tt.set.Name || !metav1.IsControlledBy(robot, tt.set) {
+					t.Errorf("Robot %q does not belong to its RobotSet", name)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRobotOutdated(t *testing.T) {
+	eu := robotv1.RobotSetParameter{Name: "eu", Values: map[string]string{"region": "eu-west-1"}}
+	render := func(set *robotv1.RobotSet) *robotv1.Robot {
+		robots, err := renderRobots(set)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		return robots["fleet-eu"]
+	}
+	current := render(newRobotSet("robot:1", eu))
+
+	tests := []struct {
+		name    string
+		current func() *robotv1.Robot
+		set     *robotv1.RobotSet
+		want    bool
+	}{
+		{
+			name:    "up to date",
+			current: func() *robotv1.Robot { return current.DeepCopy() },
+			set:     newRobotSet("robot:1", eu),
+		},
+		{
+			name: "status and foreign metadata do not matter",
+			current: func() *robotv1.Robot {
+				robot := current.DeepCopy()
+				robot.Annotations["note"] = "hello"
+				robot.Status.ObservedGeneration = 3
+				return robot
+			},
+			set: newRobotSet("robot:1", eu),
+		},
+		{
+			name:    "template changed",
+			current: func() *robotv1.Robot { return current.DeepCopy() },
+			set:     newRobotSet("robot:2", eu),
+			want:    true,
+		},
+		{
+			name: "edited since",
+			current: func() *robotv1.Robot {
+				robot := current.DeepCopy()
+				robot.Spec.Image = "robot:edited"
+				return robot
+			},
+			set:  newRobotSet("robot:1", eu),
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := robotOutdated(tt.current(), render(tt.set)); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
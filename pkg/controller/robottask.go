@@ -0,0 +1,464 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	appsinformers "k8s.io/client-go/informers/apps/v1"
+	batchinformers "k8s.io/client-go/informers/batch/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	appslister "k8s.io/client-go/listers/apps/v1"
+	batchlister "k8s.io/client-go/listers/batch/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+	clientset "robot-operator/pkg/generated/clientset/versioned"
+	robotscheme "robot-operator/pkg/generated/clientset/versioned/scheme"
+	robotinformers "robot-operator/pkg/generated/informers/externalversions/robot/v1"
+	robotlisters "robot-operator/pkg/generated/listers/robot/v1"
+)
+
+const (
+	robotTaskControllerAgentName = "robottask-controller"
+
+	// taskLabel marks the pods of a RobotTask.
+	taskLabel = "robot.llleon.io/task"
+
+	// robotContainerName is the name of the container running the image of
+	// a Robot in the pods of its Deployment.
+	robotContainerName = "nginx"
+
+	// taskLogsCommand prints the logs of the pods of a Job.
+	taskLogsCommand = "kubectl logs --namespace %s --selector job-name=%s --all-containers --prefix"
+
+	MessageRobotTaskSynced  = "RobotTask synced successfully"
+	MessageJobExists        = "Job %q already exists and is not managed by RobotTask"
+	MessageWaitingForRobot  = "Waiting for the Deployment of Robot %q"
+	MessageRobotTaskRunning = "Job %q is running"
+)
+
+// RobotTaskController runs RobotTasks as Jobs.
+type RobotTaskController struct {
+	kubeClientset  kubernetes.Interface
+	robotClientset clientset.Interface
+
+	deploymentsLister appslister.DeploymentLister
+	jobsLister        batchlister.JobLister
+	robotsLister      robotlisters.RobotLister
+	robotTasksLister  robotlisters.RobotTaskLister
+
+	deploymentsSynced cache.InformerSynced
+	jobsSynced        cache.InformerSynced
+	robotsSynced      cache.InformerSynced
+	robotTasksSynced  cache.InformerSynced
+
+	// dryRunner reports writes instead of making them in dry-run mode.
+	dryRunner
+
+	workQueue workqueue.RateLimitingInterface
+	recorder  record.EventRecorder
+}
+
+func NewRobotTaskController(
+	kubeClientset kubernetes.Interface,
+	robotClientset clientset.Interface,
+	deploymentInformer appsinformers.DeploymentInformer,
+	jobInformer batchinformers.JobInformer,
+	robotInformer robotinformers.RobotInformer,
+	robotTaskInformer robotinformers.RobotTaskInformer,
+	dryRun bool) *RobotTaskController {
+
+	utilruntime.Must(robotscheme.AddToScheme(scheme.Scheme))
+
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartStructuredLogging(0)
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClientset.CoreV1().Events("")})
+	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: robotTaskControllerAgentName})
+
+	controller := &RobotTaskController{
+		kubeClientset:     kubeClientset,
+		robotClientset:    robotClientset,
+		deploymentsLister: deploymentInformer.Lister(),
+		jobsLister:        jobInformer.Lister(),
+		robotsLister:      robotInformer.Lister(),
+		robotTasksLister:  robotTaskInformer.Lister(),
+		deploymentsSynced: deploymentInformer.Informer().HasSynced,
+		jobsSynced:        jobInformer.Informer().HasSynced,
+		robotsSynced:      robotInformer.Informer().HasSynced,
+		robotTasksSynced:  robotTaskInformer.Informer().HasSynced,
+		dryRunner:         dryRunner{dryRun: dryRun, events: recorder},
+		workQueue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "RobotTasks"),
+		recorder:          recorder,
+	}
+
+	klog.Info("Setting up RobotTask event handlers")
+	robotTaskInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: controller.enqueueRobotTask,
+		UpdateFunc: func(old, new interface{}) {
+			controller.enqueueRobotTask(new)
+		},
+	})
+
+	// Jobs change as they run
+	jobInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: controller.handleJob,
+		UpdateFunc: func(old, new interface{}) {
+			if old.(metav1.Object).GetResourceVersion() == new.(metav1.Object).GetResourceVersion() {
+				return
+			}
+
+			controller.handleJob(new)
+		},
+		DeleteFunc: controller.handleJob,
+	})
+
+	// pending RobotTasks wait for the Deployments of their Robots
+	deploymentInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: controller.handleDeployment,
+	})
+
+	return controller
+}
+
+func (c *RobotTaskController) Run(threadness int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer c.workQueue.ShutDown()
+
+	klog.Info("Starting RobotTask controller")
+
+	klog.Info("Waiting for informer caches to sync")
+	if ok := cache.WaitForCacheSync(stopCh, c.deploymentsSynced, c.jobsSynced, c.robotsSynced, c.robotTasksSynced); !ok {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	klog.Info("Starting RobotTask workers")
+	for i := 0; i < threadness; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	klog.Info("Started RobotTask workers")
+	<-stopCh
+	klog.Info("Shutting down RobotTask workers")
+
+	return nil
+}
+
+func (c *RobotTaskController) runWorker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *RobotTaskController) processNextWorkItem() bool {
+	obj, shutdown := c.workQueue.Get()
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.workQueue.Done(obj)
+
+		key, ok := obj.(string)
+		if !ok {
+			c.workQueue.Forget(obj)
+			return nil
+		}
+
+		if err := c.reconcile(key); err != nil {
+			c.workQueue.AddRateLimited(key)
+			return fmt.Errorf("error syncing '%s': %s, requeuing", key, err.Error())
+		}
+
+		c.workQueue.Forget(obj)
+		klog.Infof("Successfully synced '%s'", key)
+
+		return nil
+	}(obj)
+
+	if err != nil {
+		utilruntime.HandleError(err)
+	}
+
+	return true
+}
+
+// reconcile creates the Job of the RobotTask with key once the Deployment of
+// its Robot exists, and reports how the Job runs.
+func (c *RobotTaskController) reconcile(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("invalid resource key: %s", key))
+		return nil
+	}
+
+	task, err := c.robotTasksLister.RobotTasks(namespace).Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			utilruntime.HandleError(fmt.Errorf("RobotTask '%s' in work queue no longer exists", key))
+			return nil
+		}
+
+		return err
+	}
+
+	job, err := c.jobsLister.Jobs(task.Namespace).Get(task.Name)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	if job != nil && !metav1.IsControlledBy(job, task) {
+		msg := fmt.Sprintf(MessageJobExists, job.Name)
+		c.recorder.Event(task, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return fmt.Errorf(msg)
+	}
+
+	// a task whose Job is gone ran already, and is not run again
+	if job == nil && task.Status.JobName != "" {
+		return nil
+	}
+
+	if job == nil {
+		template, err := robotPodTemplate(c.robotsLister, c.deploymentsLister, task.Namespace, task.Spec.RobotName)
+		if err != nil {
+			return err
+		}
+		if template == nil {
+			status := task.Status.DeepCopy()
+			status.Phase = robotv1.RobotTaskPending
+			status.Message = fmt.Sprintf(MessageWaitingForRobot, task.Spec.RobotName)
+			return c.updateRobotTaskStatus(task, status)
+		}
+
+		job = newTaskJob(task, template)
+		job, err = c.kubeClientset.BatchV1().Jobs(task.Namespace).Create(context.TODO(), job, c.createOptions(task, "Job", task.Name))
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := c.updateRobotTaskStatus(task, taskStatus(job)); err != nil {
+		return err
+	}
+
+	c.recorder.Event(task, corev1.EventTypeNormal, SuccessSynced, MessageRobotTaskSynced)
+
+	return nil
+}
+
+// robotPodTemplate returns the pod template of the Deployment of the Robot
+// named name without the labels the Deployment selects on, so that pods
+// rendered from it are no pods of the Robot, nil if either does not exist
+// yet. A template without the Robot's container, e.g. edited by hand, is an
+// error.
+func robotPodTemplate(robotsLister robotlisters.RobotLister, deploymentsLister appslister.DeploymentLister, namespace, name string) (*corev1.PodTemplateSpec, error) {
+	robot, err := robotsLister.Robots(namespace).Get(name)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	deployment, err := deploymentsLister.Deployments(namespace).Get(robot.Spec.DeploymentName)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !metav1.IsControlledBy(deployment, robot) {
+		return nil, nil
+	}
+
+	template := deployment.Spec.Template.DeepCopy()
+	if deployment.Spec.Selector != nil {
+		for key := range deployment.Spec.Selector.MatchLabels {
+			delete(template.Labels, key)
+		}
+	}
+	if robotContainer(template) == nil {
+		return nil, fmt.Errorf("Deployment %q has no container %q", deployment.Name, robotContainerName)
+	}
+
+	return template, nil
+}
+
+// robotContainer returns the container of template running the image of the
+// Robot, nil if there is none.
+func robotContainer(template *corev1.PodTemplateSpec) *corev1.Container {
+	for i := range template.Spec.Containers {
+		if template.Spec.Containers[i].Name == robotContainerName {
+			return &template.Spec.Containers[i]
+		}
+	}
+
+	return nil
+}
+
+// taskPodTemplate turns template, the pod template of the Deployment of a
+// Robot as returned by robotPodTemplate, into one labelled with taskLabels
+// running command and args in the Robot's container. The sidecars are left
+// out, as they would keep the pod from completing, and so is the instance
+// label, so that the pod is no pod of the Robot.
+func taskPodTemplate(template *corev1.PodTemplateSpec, taskLabels map[string]string, command, args []string) corev1.PodTemplateSpec {
+	template = template.DeepCopy()
+
+	delete(template.Labels, instanceLabel)
+	template.Labels = merge(template.Labels, taskLabels)
+
+	container := *robotContainer(template)
+	if command != nil {
+		container.Command = command
+	}
+	if args != nil {
+		container.Args = args
+	}
+	template.Spec.Containers = []corev1.Container{container}
+	template.Spec.RestartPolicy = corev1.RestartPolicyNever
+
+	return *template
+}
+
+// newTaskJob renders the Job of task from template, the pod template of the
+// Deployment of its Robot.
+func newTaskJob(task *robotv1.RobotTask, template *corev1.PodTemplateSpec) *batchv1.Job {
+	podTemplate := taskPodTemplate(template, map[string]string{taskLabel: task.Name}, task.Spec.Command, task.Spec.Args)
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      task.Name,
+			Namespace: task.Namespace,
+			Labels:    podTemplate.Labels,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(task, robotv1.SchemeGroupVersion.WithKind("RobotTask")),
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          task.Spec.BackoffLimit,
+			ActiveDeadlineSeconds: task.Spec.ActiveDeadlineSeconds,
+			Template:              podTemplate,
+		},
+	}
+}
+
+// taskStatus returns the status of a RobotTask running as job.
+func taskStatus(job *batchv1.Job) *robotv1.RobotTaskStatus {
+	status := &robotv1.RobotTaskStatus{
+		Phase:          robotv1.RobotTaskRunning,
+		JobName:        job.Name,
+		StartTime:      job.Status.StartTime,
+		CompletionTime: job.Status.CompletionTime,
+		Retries:        job.Status.Failed,
+		Message:        fmt.Sprintf(MessageRobotTaskRunning, job.Name),
+		Logs:           fmt.Sprintf(taskLogsCommand, job.Namespace, job.Name),
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			status.Phase = robotv1.RobotTaskSucceeded
+			status.Message = ""
+		case batchv1.JobFailed:
+			status.Phase = robotv1.RobotTaskFailed
+			status.Message = condition.Message
+			if status.CompletionTime == nil {
+				completionTime := condition.LastTransitionTime
+				status.CompletionTime = &completionTime
+			}
+		}
+	}
+
+	return status
+}
+
+// updateRobotTaskStatus writes status to task, unless it is already up to
+// date.
+func (c *RobotTaskController) updateRobotTaskStatus(task *robotv1.RobotTask, status *robotv1.RobotTaskStatus) error {
+	if equality.Semantic.DeepEqual(task.Status, *status) {
+		return nil
+	}
+
+	taskCopy := task.DeepCopy()
+	taskCopy.Status = *status
+	_, err := c.robotClientset.RobotV1().RobotTasks(task.Namespace).UpdateStatus(context.TODO(), taskCopy, c.updateOptions(task, "RobotTask", task.Name))
+
+	return err
+}
+
+// handleJob enqueues the RobotTask controlling the Job obj.
+func (c *RobotTaskController) handleJob(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	job, ok := obj.(*batchv1.Job)
+	if !ok {
+		utilruntime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+		return
+	}
+
+	ownerRef := metav1.GetControllerOf(job)
+	if ownerRef == nil || ownerRef.Kind != "RobotTask" {
+		return
+	}
+
+	task, err := c.robotTasksLister.RobotTasks(job.Namespace).Get(ownerRef.Name)
+	if err != nil {
+		klog.V(4).Infof("ignoring orphaned Job '%s' of RobotTask '%s'", job.Name, ownerRef.Name)
+		return
+	}
+
+	c.enqueueRobotTask(task)
+}
+
+// handleDeployment enqueues the pending RobotTasks of the Robot controlling
+// the Deployment obj.
+func (c *RobotTaskController) handleDeployment(obj interface{}) {
+	deployment, ok := obj.(*appsv1.Deployment)
+	if !ok {
+		utilruntime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+		return
+	}
+
+	ownerRef := metav1.GetControllerOf(deployment)
+	if ownerRef == nil || ownerRef.Kind != "Robot" {
+		return
+	}
+
+	tasks, err := c.robotTasksLister.RobotTasks(deployment.Namespace).List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	for _, task := range tasks {
+		if task.Spec.RobotName == ownerRef.Name && task.Status.JobName == "" {
+			c.enqueueRobotTask(task)
+		}
+	}
+}
+
+func (c *RobotTaskController) enqueueRobotTask(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+
+	c.workQueue.Add(key)
+}
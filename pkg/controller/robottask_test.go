@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	appslister "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+	robotlisters "robot-operator/pkg/generated/listers/robot/v1"
+)
+
+func newTaskRobot() *robotv1.Robot {
+	return &robotv1.Robot{
+		ObjectMeta: metav1.ObjectMeta{Name: "robot", Namespace: metav1.NamespaceDefault, UID: "robot-uid"},
+		Spec: robotv1.RobotSpec{
+			DeploymentName: "robot",
+			Image:          "robot:1",
+			Labels:         map[string]string{"team": "robots"},
+			Sidecars:       []corev1.Container{{Name: "proxy", Image: "envoy"}},
+		},
+	}
+}
+
+func TestRobotPodTemplate(t *testing.T) {
+	robot := newTaskRobot()
+
+	tests := []struct {
+		name       string
+		deployment func() *appsv1.Deployment
+		wantLabels map[string]string
+		wantNil    bool
+		wantErr    bool
+	}{
+		{
+			name:       "selector labels are stripped",
+			deployment: func() *appsv1.Deployment { return newDeployment(robot, "", nil) },
+			wantLabels: map[string]string{"team": "robots", managedByLabel: controllerAgentName},
+		},
+		{
+			name: "every label of an adopted selector is stripped",
+			deployment: func() *appsv1.Deployment {
+				deployment := newDeployment(robot, "", nil)
+				deployment.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"team": "robots"}}
+				return deployment
+			},
+			wantLabels: map[string]string{nameLabel: appName, instanceLabel: robot.Name, managedByLabel: controllerAgentName},
+		},
+		{
+			name:    "no Deployment",
+			wantNil: true,
+		},
+		{
+			name: "Deployment of someone else",
+			deployment: func() *appsv1.Deployment {
+				deployment := newDeployment(robot, "", nil)
+				deployment.OwnerReferences = nil
+				return deployment
+			},
+			wantNil: true,
+		},
+		{
+			name: "Robot container edited away",
+			deployment: func() *appsv1.Deployment {
+				deployment := newDeployment(robot, "", nil)
+				deployment.Spec.Template.Spec.Containers[0].Name = "app"
+				return deployment
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			robots := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			robots.Add(robot)
+			deployments := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			if tt.deployment != nil {
+				deployments.Add(tt.deployment())
+			}
+
+			template, err := robotPodTemplate(robotlisters.NewRobotLister(robots), appslister.NewDeploymentLister(deployments), robot.Namespace, robot.Name)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %+v", template)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if tt.wantNil {
+				if template != nil {
+					t.Errorf("got %+v, want none", template)
+				}
+				return
+			}
+			if !reflect.DeepEqual(template.Labels, tt.wantLabels) {
+				t.Errorf("got labels %v, want %v", template.Labels, tt.wantLabels)
+			}
+		})
+	}
+}
+
+func TestTaskPodTemplate(t *testing.T) {
+	// the template of a Deployment selecting on other labels than the
+	// instance label, which robotPodTemplate leaves in
+	template := newDeployment(newTaskRobot(), "", nil).Spec.Template
+	delete(template.Labels, nameLabel)
+
+	tests := []struct {
+		name        string
+		command     []string
+		args        []string
+		wantCommand []string
+		wantArgs    []string
+	}{
+		{
+			name: "image defaults",
+		},
+		{
+			name:        "command and args",
+			command:     []string{"/bin/migrate"},
+			args:        []string{"--up"},
+			wantCommand: []string{"/bin/migrate"},
+			wantArgs:    []string{"--up"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := taskPodTemplate(&template, map[string]string{taskLabel: "migrate"}, tt.command, tt.args)
+
+			wantLabels := map[string]string{"team": "robots", managedByLabel: controllerAgentName, taskLabel: "migrate"}
+			if !reflect.DeepEqual(got.Labels, wantLabels) {
+				t.Errorf("got labels %v, want %v", got.Labels, wantLabels)
+			}
+			if len(got.Spec.Containers) != 1 || got.Spec.Containers[0].Name != robotContainerName || got.Spec.Containers[0].Image != "robot:1" {
+				t.Fatalf("got containers %+v, want the Robot's only", got.Spec.Containers)
+			}
+			if container := got.Spec.Containers[0]; !reflect.DeepEqual(container.Command, tt.wantCommand) || !reflect.DeepEqual(container.Args, tt.wantArgs) {
+				t.Errorf("got command %q and args %q, want %q and %q", container.Command, container.Args, tt.wantCommand, tt.wantArgs)
+			}
+			if got.Spec.RestartPolicy != corev1.RestartPolicyNever {
+				t.Errorf("got restart policy %q, want %q", got.Spec.RestartPolicy, corev1.RestartPolicyNever)
+			}
+			if len(template.Spec.Containers) != 2 || template.Labels[instanceLabel] == "" {
+				t.Errorf("the template was changed: %+v", template)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"fmt"
+
+	metrics "github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+const (
+	// admissionSource and reconcileSource tell where a rule denied a Robot.
+	admissionSource = "admission"
+	reconcileSource = "reconcile"
+
+	RuleViolation        = "RuleViolation"
+	MessageRuleViolation = "Robot fails its rules: %s"
+)
+
+// ruleDenials counts the times each rule of the operator config denied a
+// Robot: at admission each time it is written, and when it is reconciled
+// once each time it starts failing its rules.
+var ruleDenials = metrics.NewCounterVec(metrics.CounterOpts{
+	Name: "robot_operator_rule_denials_total",
+	Help: "Number of times a rule of the operator config denied a Robot.",
+}, []string{"rule", "source"})
+
+func init() {
+	metrics.MustRegister(ruleDenials)
+}
+
+// ruleViolation is a rule of the operator config failed by a Robot.
+type ruleViolation struct {
+	rule    string
+	message string
+}
+
+// evaluateRules returns the rules of the operator config robot fails. A rule
+// which cannot be evaluated against robot, e.g. because it reads a field
+// robot does not set, denies it.
+func (c *Controller) evaluateRules(robot *robotv1.Robot) ([]ruleViolation, error) {
+	if c.config == nil || len(c.config.Rules) == 0 {
+		return nil, nil
+	}
+
+	object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(robot)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []ruleViolation
+	for i := range c.config.Rules {
+		rule := &c.config.Rules[i]
+		allowed, err := rule.Allows(object)
+		switch {
+		case err != nil:
+			violations = append(violations, ruleViolation{rule: rule.Name, message: fmt.Sprintf("rule %q: %s", rule.Name, err.Error())})
+		case !allowed:
+			violations = append(violations, ruleViolation{rule: rule.Name, message: fmt.Sprintf("rule %q: %s", rule.Name, rule.DenialMessage())})
+		}
+	}
+
+	return violations, nil
+}
+
+// countRuleDenials counts violations as denials under source.
+func countRuleDenials(violations []ruleViolation, source string) {
+	for _, violation := range violations {
+		ruleDenials.WithLabelValues(violation.rule, source).Inc()
+	}
+}
+
+// ruleMessages returns the messages of violations.
+func ruleMessages(violations []ruleViolation) []string {
+	messages := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		messages = append(messages, violation.message)
+	}
+
+	return messages
+}
@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+const (
+	ErrSchedule     = "ErrSchedule"
+	MessageSchedule = "Schedule %q is invalid: %s"
+)
+
+// syncSchedules returns the schedule of robot in effect at now, nil if there
+// is none, and records it in status along with the time the schedules next
+// start or end. Invalid schedules are reported and ignored.
+func (c *Controller) syncSchedules(robot *robotv1.Robot, status *robotv1.RobotStatus, now time.Time) *robotv1.RobotSchedule {
+	var (
+		active *robotv1.RobotSchedule
+		next   time.Time
+	)
+
+	for i := range robot.Spec.Schedules {
+		schedule := &robot.Spec.Schedules[i]
+
+		start, end, err := scheduleWindow(schedule, now)
+		if err != nil {
+			c.recorder.Eventf(robot, corev1.EventTypeWarning, ErrSchedule, MessageSchedule, schedule.Name, err.Error())
+			continue
+		}
+
+		transition := start
+		if !start.After(now) {
+			if active == nil {
+				active = schedule
+			}
+			transition = end
+		}
+		if next.IsZero() || transition.Before(next) {
+			next = transition
+		}
+	}
+
+	status.ActiveSchedule = ""
+	if active != nil {
+		status.ActiveSchedule = active.Name
+	}
+	status.NextScheduleTransition = nil
+	if !next.IsZero() {
+		status.NextScheduleTransition = &metav1.Time{Time: next}
+	}
+
+	return active
+}
+
+// scheduleWindow returns when schedule starts and ends. The schedule is in
+// effect at now if it starts no later than now, otherwise start is its next
+// start.
+func scheduleWindow(schedule *robotv1.RobotSchedule, now time.Time) (time.Time, time.Time, error) {
+	if schedule.Duration.Duration <= 0 {
+		return time.Time{}, time.Time{}, fmt.Errorf("duration must be positive")
+	}
+
+	location := time.UTC
+	if schedule.TimeZone != "" {
+		var err error
+		if location, err = time.LoadLocation(schedule.TimeZone); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+
+	expression, err := cron.ParseStandard(schedule.Cron)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	// the first start after now-duration is either still in effect or the
+	// next one
+	start := expression.Next(now.In(location).Add(-schedule.Duration.Duration))
+	if start.IsZero() {
+		return time.Time{}, time.Time{}, fmt.Errorf("cron expression never matches")
+	}
+
+	return start, start.Add(schedule.Duration.Duration), nil
+}
@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+func TestScheduleWindow(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	officeHours := robotv1.RobotSchedule{Cron: "0 9 * * 1-5", Duration: metav1.Duration{Duration: 8 * time.Hour}, TimeZone: "Europe/Paris"}
+
+	tests := []struct {
+		name      string
+		schedule  robotv1.RobotSchedule
+		now       time.Time
+		wantStart time.Time
+		wantErr   bool
+	}{
+		{
+			name:      "in effect",
+			schedule:  officeHours,
+			now:       time.Date(2021, 1, 4, 10, 0, 0, 0, paris),
+			wantStart: time.Date(2021, 1, 4, 9, 0, 0, 0, paris),
+		},
+		{
+			name:      "before the start",
+			schedule:  officeHours,
+			now:       time.Date(2021, 1, 4, 8, 0, 0, 0, paris),
+			wantStart: time.Date(2021, 1, 4, 9, 0, 0, 0, paris),
+		},
+		{
+			name:      "after the end",
+			schedule:  officeHours,
+			now:       time.Date(2021, 1, 8, 17, 0, 0, 0, paris),
+			wantStart: time.Date(2021, 1, 11, 9, 0, 0, 0, paris),
+		},
+		{
+			name:      "daylight saving time",
+			schedule:  officeHours,
+			now:       time.Date(2021, 3, 29, 6, 0, 0, 0, time.UTC),
+			wantStart: time.Date(2021, 3, 29, 7, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "across midnight in UTC by default",
+			schedule:  robotv1.RobotSchedule{Cron: "0 22 * * *", Duration: metav1.Duration{Duration: 4 * time.Hour}},
+			now:       time.Date(2021, 1, 5, 1, 0, 0, 0, time.UTC),
+			wantStart: time.Date(2021, 1, 4, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "no duration",
+			schedule: robotv1.RobotSchedule{Cron: "0 9 * * *"},
+			wantErr:  true,
+		},
+		{
+			name:     "unknown time zone",
+			schedule: robotv1.RobotSchedule{Cron: "0 9 * * *", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: "Mars/Olympus"},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid cron expression",
+			schedule: robotv1.RobotSchedule{Cron: "every morning", Duration: metav1.Duration{Duration: time.Hour}},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := scheduleWindow(&tt.schedule, tt.now)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %s", start)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if !start.Equal(tt.wantStart) {
+				t.Errorf("got start %s, want %s", start, tt.wantStart)
+			}
+			if wantEnd := tt.wantStart.Add(tt.schedule.Duration.Duration); !end.Equal(wantEnd) {
+				t.Errorf("got end %s, want %s", end, wantEnd)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+const (
+	zoneTopologyKey     = "topology.kubernetes.io/zone"
+	hostnameTopologyKey = "kubernetes.io/hostname"
+)
+
+// applyScheduling sets the scheduling fields of robot on spec, expanding the
+// spread shorthands into topology spread constraints and pod anti-affinity on
+// the labels of the Robot's pods.
+func applyScheduling(robot *robotv1.Robot, spec *corev1.PodSpec) {
+	spec.NodeSelector = robot.Spec.NodeSelector
+	spec.Affinity = robot.Spec.Affinity.DeepCopy()
+	spec.Tolerations = robot.Spec.Tolerations
+	spec.PriorityClassName = robot.Spec.PriorityClassName
+
+	selector := &metav1.LabelSelector{MatchLabels: selectorLabels(robot)}
+
+	if robot.Spec.SpreadAcrossZones {
+		spec.TopologySpreadConstraints = append(spec.TopologySpreadConstraints, corev1.TopologySpreadConstraint{
+			MaxSkew:           1,
+			TopologyKey:       zoneTopologyKey,
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector:     selector,
+		})
+	}
+
+	if robot.Spec.SpreadAcrossNodes {
+		spec.TopologySpreadConstraints = append(spec.TopologySpreadConstraints, corev1.TopologySpreadConstraint{
+			MaxSkew:           1,
+			TopologyKey:       hostnameTopologyKey,
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector:     selector,
+		})
+
+		if spec.Affinity == nil {
+			spec.Affinity = &corev1.Affinity{}
+		}
+		if spec.Affinity.PodAntiAffinity == nil {
+			spec.Affinity.PodAntiAffinity = &corev1.PodAntiAffinity{}
+		}
+		antiAffinity := spec.Affinity.PodAntiAffinity
+		antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution, corev1.WeightedPodAffinityTerm{
+			Weight: 100,
+			PodAffinityTerm: corev1.PodAffinityTerm{
+				LabelSelector: selector,
+				TopologyKey:   hostnameTopologyKey,
+			},
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+const (
+	secretVolumeName = "robot-secrets"
+
+	defaultSecretLength = 32
+	maxSecretLength     = 1024
+
+	alphanumeric = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+)
+
+var charsets = map[robotv1.SecretCharset]string{
+	robotv1.SecretCharsetAlphanumeric: alphanumeric,
+	robotv1.SecretCharsetNumeric:      "0123456789",
+	robotv1.SecretCharsetHex:          "0123456789abcdef",
+	robotv1.SecretCharsetSymbols:      alphanumeric + "!#$%&()*+,-./:;<=>?@[]^_{|}~",
+}
+
+// secretName returns the name of the Secret owned by robot.
+func secretName(robot *robotv1.Robot) string {
+	return robot.Name + "-secrets"
+}
+
+// syncSecret makes the Secret owned by robot hold a value for every entry of
+// spec.secrets, deleting it once spec.secrets is emptied, and returns its
+// data. Existing values are kept unless the regenerate annotation of robot
+// changed since they were generated.
+func (c *Controller) syncSecret(robot *robotv1.Robot) (map[string][]byte, error) {
+	name := secretName(robot)
+	secret, err := c.secretsLister.Secrets(robot.Namespace).Get(name)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	if len(robot.Spec.Secrets) == 0 {
+		if secret != nil && metav1.IsControlledBy(secret, robot) {
+			err = c.kubeClientset.CoreV1().Secrets(robot.Namespace).Delete(context.TODO(), name, c.deleteOptions(robot, "Secret", name))
+			if err != nil && !errors.IsNotFound(err) {
+				return nil, err
+			}
+		}
+		return nil, nil
+	}
+
+	if secret != nil && !metav1.IsControlledBy(secret, robot) {
+		msg := fmt.Sprintf(MessageResourceExists, name)
+		c.recorder.Event(robot, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return nil, fmt.Errorf(msg)
+	}
+
+	regenerateAt := robot.Annotations[robotv1.RegenerateSecretsAtAnnotation]
+
+	var existing map[string][]byte
+	if secret != nil && secret.Annotations[robotv1.RegenerateSecretsAtAnnotation] == regenerateAt {
+		existing = secret.Data
+	}
+
+	data := make(map[string][]byte, len(robot.Spec.Secrets))
+	for _, entry := range robot.Spec.Secrets {
+		if value, ok := existing[entry.Key]; ok {
+			data[entry.Key] = value
+			continue
+		}
+
+		value, err := generateSecret(entry)
+		if err != nil {
+			return nil, err
+		}
+		data[entry.Key] = value
+	}
+
+	if secret == nil {
+		_, err = c.kubeClientset.CoreV1().Secrets(robot.Namespace).Create(context.TODO(), newSecret(robot, data, regenerateAt), c.createOptions(robot, "Secret", name))
+		return data, err
+	}
+
+	if !reflect.DeepEqual(secret.Data, data) || secret.Annotations[robotv1.RegenerateSecretsAtAnnotation] != regenerateAt || metadataChanged(secret, robot) {
+		secretCopy := secret.DeepCopy()
+		secretCopy.Data = data
+		applyMetadata(secretCopy, robot)
+		secretCopy.Annotations = merge(secretCopy.Annotations, map[string]string{
+			robotv1.RegenerateSecretsAtAnnotation: regenerateAt,
+		})
+		_, err = c.kubeClientset.CoreV1().Secrets(robot.Namespace).Update(context.TODO(), secretCopy, c.updateOptions(robot, "Secret", name))
+	}
+
+	return data, err
+}
+
+// generateSecret returns a random value as described by entry.
+func generateSecret(entry robotv1.GeneratedSecret) ([]byte, error) {
+	length := int(entry.Length)
+	if length <= 0 {
+		length = defaultSecretLength
+	}
+	if length > maxSecretLength {
+		return nil, fmt.Errorf("secret %q: length %d exceeds %d", entry.Key, length, maxSecretLength)
+	}
+
+	charset := alphanumeric
+	if entry.Charset != "" {
+		var ok bool
+		if charset, ok = charsets[entry.Charset]; !ok {
+			return nil, fmt.Errorf("secret %q: unknown charset %q", entry.Key, entry.Charset)
+		}
+	}
+
+	max := big.NewInt(int64(len(charset)))
+	value := make([]byte, length)
+	for i := range value {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return nil, err
+		}
+		value[i] = charset[n.Int64()]
+	}
+
+	return value, nil
+}
+
+// secretVolumes returns the volume, mounts and environment variables that
+// expose the generated secrets of robot to its container.
+func secretVolumes(robot *robotv1.Robot) ([]corev1.Volume, []corev1.VolumeMount, []corev1.EnvVar) {
+	var (
+		volumes []corev1.Volume
+		mounts  []corev1.VolumeMount
+		env     []corev1.EnvVar
+	)
+
+	for _, entry := range robot.Spec.Secrets {
+		name := entry.Env
+		if name == "" && entry.MountPath == "" {
+			name = entry.Key
+		}
+
+		if name != "" {
+			env = append(env, corev1.EnvVar{
+				Name: name,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: secretName(robot)},
+						Key:                  entry.Key,
+					},
+				},
+			})
+		}
+
+		if entry.MountPath != "" {
+			mounts = append(mounts, corev1.VolumeMount{
+				Name:      secretVolumeName,
+				MountPath: entry.MountPath,
+				SubPath:   entry.Key,
+				ReadOnly:  true,
+			})
+		}
+	}
+
+	if len(mounts) > 0 {
+		volumes = append(volumes, corev1.Volume{
+			Name: secretVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: secretName(robot)},
+			},
+		})
+	}
+
+	return volumes, mounts, env
+}
+
+func newSecret(robot *robotv1.Robot, data map[string][]byte, regenerateAt string) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: objectMeta(robot, secretName(robot)),
+		Type:       corev1.SecretTypeOpaque,
+		Data:       data,
+	}
+	secret.Annotations[robotv1.RegenerateSecretsAtAnnotation] = regenerateAt
+
+	return secret
+}
@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+// sidecarContainers returns the sidecars of robot: the defaults it does not
+// opt out of nor replace, followed by its own.
+func sidecarContainers(robot *robotv1.Robot, defaults []corev1.Container) []corev1.Container {
+	skip := map[string]bool{}
+	switch value := robot.Annotations[robotv1.SkipDefaultSidecarsAnnotation]; value {
+	case "":
+	case "true":
+		defaults = nil
+	default:
+		for _, name := range strings.Split(value, ",") {
+			skip[strings.TrimSpace(name)] = true
+		}
+	}
+	for _, sidecar := range robot.Spec.Sidecars {
+		skip[sidecar.Name] = true
+	}
+
+	var sidecars []corev1.Container
+	for _, sidecar := range defaults {
+		if !skip[sidecar.Name] {
+			sidecars = append(sidecars, *sidecar.DeepCopy())
+		}
+	}
+	for _, sidecar := range robot.Spec.Sidecars {
+		sidecars = append(sidecars, *sidecar.DeepCopy())
+	}
+
+	return sidecars
+}
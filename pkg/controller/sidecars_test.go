@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+// containerNames returns the names of containers, with their image.
+func containerNames(containers []corev1.Container) []string {
+	var names []string
+	for _, container := range containers {
+		names = append(names, container.Name+"="+container.Image)
+	}
+
+	return names
+}
+
+func TestSidecarContainers(t *testing.T) {
+	defaults := []corev1.Container{
+		{Name: "proxy", Image: "envoy"},
+		{Name: "logs", Image: "fluent-bit"},
+	}
+
+	tests := []struct {
+		name     string
+		skip     string
+		sidecars []corev1.Container
+		want     []string
+	}{
+		{
+			name: "defaults",
+			want: []string{"proxy=envoy", "logs=fluent-bit"},
+		},
+		{
+			name:     "own sidecars follow the defaults",
+			sidecars: []corev1.Container{{Name: "metrics", Image: "exporter"}},
+			want:     []string{"proxy=envoy", "logs=fluent-bit", "metrics=exporter"},
+		},
+		{
+			name:     "own sidecar replaces the default of its name",
+			sidecars: []corev1.Container{{Name: "proxy", Image: "linkerd"}},
+			want:     []string{"logs=fluent-bit", "proxy=linkerd"},
+		},
+		{
+			name: "opt out of every default",
+			skip: "true",
+			want: nil,
+		},
+		{
+			name:     "opt out of named defaults",
+			skip:     "proxy, logs",
+			sidecars: []corev1.Container{{Name: "metrics", Image: "exporter"}},
+			want:     []string{"metrics=exporter"},
+		},
+		{
+			name: "opt out of an unknown default",
+			skip: "tracing",
+			want: []string{"proxy=envoy", "logs=fluent-bit"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			robot := &robotv1.Robot{Spec: robotv1.RobotSpec{Sidecars: tt.sidecars}}
+			if tt.skip != "" {
+				robot.ObjectMeta = metav1.ObjectMeta{Annotations: map[string]string{robotv1.SkipDefaultSidecarsAnnotation: tt.skip}}
+			}
+
+			got := sidecarContainers(robot, defaults)
+			if !reflect.DeepEqual(containerNames(got), tt.want) {
+				t.Errorf("got %v, want %v", containerNames(got), tt.want)
+			}
+		})
+	}
+}
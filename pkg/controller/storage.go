@@ -0,0 +1,167 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+const (
+	// robotLabel marks the claims provisioned for a Robot, including the
+	// retained ones which have no owner reference.
+	robotLabel = "robot.llleon.io/robot"
+
+	storageVolumeName       = "robot-data"
+	defaultStorageMountPath = "/data"
+
+	ErrStorageShrink     = "ErrStorageShrink"
+	MessageStorageShrink = "Claim %q can not shrink from %s to %s"
+)
+
+// claimName returns the name of the PersistentVolumeClaim of robot.
+func claimName(robot *robotv1.Robot) string {
+	return robot.Name + "-data"
+}
+
+// syncClaim provisions the PersistentVolumeClaim of robot and expands it when
+// spec.storage.size grows. The claim is owned by robot only when its reclaim
+// policy is Delete, so that deleting the Robot garbage collects it.
+func (c *Controller) syncClaim(robot *robotv1.Robot) error {
+	storage := robot.Spec.Storage
+	name := claimName(robot)
+
+	claim, err := c.claimsLister.PersistentVolumeClaims(robot.Namespace).Get(name)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	if storage == nil {
+		// a retained claim outlives the storage section as well
+		if claim != nil && metav1.IsControlledBy(claim, robot) {
+			err = c.kubeClientset.CoreV1().PersistentVolumeClaims(robot.Namespace).Delete(context.TODO(), name, c.deleteOptions(robot, "PersistentVolumeClaim", name))
+			if err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if claim != nil && !metav1.IsControlledBy(claim, robot) && claim.Labels[robotLabel] != robot.Name {
+		msg := fmt.Sprintf(MessageResourceExists, name)
+		c.recorder.Event(robot, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return fmt.Errorf(msg)
+	}
+
+	if claim == nil {
+		_, err = c.kubeClientset.CoreV1().PersistentVolumeClaims(robot.Namespace).Create(context.TODO(), newClaim(robot), c.createOptions(robot, "PersistentVolumeClaim", name))
+		return err
+	}
+
+	claimCopy := claim.DeepCopy()
+	changed := false
+
+	if metadataChanged(claim, robot) {
+		applyMetadata(claimCopy, robot)
+		changed = true
+	}
+
+	owned := metav1.IsControlledBy(claim, robot)
+	if retain := reclaimPolicy(storage) == robotv1.StorageReclaimRetain; retain && owned {
+		claimCopy.OwnerReferences = nil
+		changed = true
+	} else if !retain && !owned {
+		claimCopy.OwnerReferences = []metav1.OwnerReference{
+			*metav1.NewControllerRef(robot, robotv1.SchemeGroupVersion.WithKind("Robot")),
+		}
+		changed = true
+	}
+
+	current := claim.Spec.Resources.Requests[corev1.ResourceStorage]
+	switch current.Cmp(storage.Size) {
+	case -1:
+		if claimCopy.Spec.Resources.Requests == nil {
+			claimCopy.Spec.Resources.Requests = corev1.ResourceList{}
+		}
+		claimCopy.Spec.Resources.Requests[corev1.ResourceStorage] = storage.Size
+		changed = true
+	case 1:
+		c.recorder.Eventf(robot, corev1.EventTypeWarning, ErrStorageShrink, MessageStorageShrink, name, current.String(), storage.Size.String())
+	}
+
+	if changed {
+		_, err = c.kubeClientset.CoreV1().PersistentVolumeClaims(robot.Namespace).Update(context.TODO(), claimCopy, c.updateOptions(robot, "PersistentVolumeClaim", name))
+	}
+
+	return err
+}
+
+func reclaimPolicy(storage *robotv1.RobotStorage) robotv1.StorageReclaimPolicy {
+	if storage.ReclaimPolicy == "" {
+		return robotv1.StorageReclaimRetain
+	}
+
+	return storage.ReclaimPolicy
+}
+
+// storageVolumes returns the volume and mount of the claim of robot.
+func storageVolumes(robot *robotv1.Robot) ([]corev1.Volume, []corev1.VolumeMount) {
+	if robot.Spec.Storage == nil {
+		return nil, nil
+	}
+
+	mountPath := robot.Spec.Storage.MountPath
+	if mountPath == "" {
+		mountPath = defaultStorageMountPath
+	}
+
+	volumes := []corev1.Volume{
+		{
+			Name: storageVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName(robot)},
+			},
+		},
+	}
+	mounts := []corev1.VolumeMount{
+		{
+			Name:      storageVolumeName,
+			MountPath: mountPath,
+		},
+	}
+
+	return volumes, mounts
+}
+
+func newClaim(robot *robotv1.Robot) *corev1.PersistentVolumeClaim {
+	storage := robot.Spec.Storage
+
+	accessModes := storage.AccessModes
+	if len(accessModes) == 0 {
+		accessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	}
+
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: objectMeta(robot, claimName(robot)),
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      accessModes,
+			StorageClassName: storage.StorageClassName,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: storage.Size,
+				},
+			},
+		},
+	}
+
+	claim.Labels[robotLabel] = robot.Name
+	if reclaimPolicy(storage) == robotv1.StorageReclaimRetain {
+		claim.OwnerReferences = nil
+	}
+
+	return claim
+}
@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+const (
+	RobotSuspended        = "Suspended"
+	MessageRobotSuspended = "Robot suspended, scaled down from %d replicas"
+	RobotResumed          = "Resumed"
+	MessageRobotResumed   = "Robot resumed, scaled back to %d replicas"
+	RobotIdle             = "Idle"
+	MessageRobotIdle      = "Robot idle, scaled down from %d replicas"
+)
+
+// desiredReplicas returns the replicas the Deployment of robot should run,
+// nil to leave them as they are. The schedule in effect, if any, overrides
+// spec.replicas and spec.suspend, and an idle robot is suspended too.
+// Suspending robot records the replicas deployment ran in status, and
+// resuming it restores them unless the spec says otherwise.
+func (c *Controller) desiredReplicas(robot *robotv1.Robot, schedule *robotv1.RobotSchedule, idle bool, deployment *appsv1.Deployment, status *robotv1.RobotStatus) *int32 {
+	suspend, replicas := robot.Spec.Suspend, robot.Spec.Replicas
+	if schedule != nil {
+		suspend = schedule.Suspend
+		if schedule.Replicas != nil {
+			replicas = schedule.Replicas
+		}
+	}
+
+	reason, message := RobotSuspended, "Deployment is scaled to zero"
+	switch {
+	case suspend && schedule != nil:
+		message += fmt.Sprintf(" by schedule %q", schedule.Name)
+	case !suspend && idle:
+		suspend = true
+		reason, message = RobotIdle, "Deployment is scaled to zero until the Robot is activated"
+	}
+
+	if suspend {
+		if status.SuspendedReplicas == nil {
+			prior := int32(1)
+			switch {
+			case deployment != nil && deployment.Spec.Replicas != nil && *deployment.Spec.Replicas > 0:
+				prior = *deployment.Spec.Replicas
+			case robot.Spec.Replicas != nil:
+				prior = *robot.Spec.Replicas
+			}
+			status.SuspendedReplicas = &prior
+			if reason == RobotIdle {
+				c.recorder.Eventf(robot, corev1.EventTypeNormal, RobotIdle, MessageRobotIdle, prior)
+			} else {
+				c.recorder.Eventf(robot, corev1.EventTypeNormal, RobotSuspended, MessageRobotSuspended, prior)
+			}
+		}
+		setCondition(status, robotv1.RobotSuspended, corev1.ConditionTrue, reason, message)
+
+		zero := int32(0)
+		return &zero
+	}
+
+	if status.SuspendedReplicas != nil {
+		if replicas == nil {
+			replicas = status.SuspendedReplicas
+		}
+		c.recorder.Eventf(robot, corev1.EventTypeNormal, RobotResumed, MessageRobotResumed, *replicas)
+		status.SuspendedReplicas = nil
+	}
+	removeCondition(status, robotv1.RobotSuspended)
+
+	return replicas
+}
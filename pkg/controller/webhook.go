@@ -0,0 +1,205 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+)
+
+const (
+	// validateRobotPath is where the API server sends the admission reviews
+	// of Robots.
+	validateRobotPath = "/validate-robot"
+)
+
+// ServeWebhook serves the validating admission webhook on address over TLS
+// until stopCh is closed. Robots failing a rule of the operator config or
+// violating a RobotPolicy or a ClusterRobotPolicy are denied when created or
+// updated.
+func (c *Controller) ServeWebhook(address, certFile, keyFile string, stopCh <-chan struct{}) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(validateRobotPath, c.validateRobot)
+	server := &http.Server{Addr: address, Handler: mux}
+
+	go func() {
+		<-stopCh
+		server.Shutdown(context.Background())
+	}()
+
+	klog.Infof("Serving validating webhook on %s", address)
+	if err := server.ListenAndServeTLS(certFile, keyFile); err != http.ErrServerClosed {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Controller) validateRobot(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !c.robotClassesSynced() || !c.robotPoliciesSynced() || !c.clusterRobotPoliciesSynced() {
+		http.Error(w, "caches not synced yet", http.StatusServiceUnavailable)
+		return
+	}
+
+	var review admissionv1.AdmissionReview
+	if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+		http.Error(w, fmt.Sprintf("invalid admission review: %s", err.Error()), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "admission review holds no request", http.StatusBadRequest)
+		return
+	}
+
+	// errors are answered with a server error rather than a denial, so that
+	// the failure policy of the webhook decides
+	denials, err := c.admitRobot(review.Request)
+	if err != nil {
+		klog.Errorf("Error validating %s/%s: %s", review.Request.Namespace, review.Request.Name, err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := &admissionv1.AdmissionResponse{UID: review.Request.UID, Allowed: len(denials) == 0}
+	if !response.Allowed {
+		response.Result = &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Code:    http.StatusForbidden,
+			Reason:  metav1.StatusReasonForbidden,
+			Message: strings.Join(denials, ". "),
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(admissionv1.AdmissionReview{TypeMeta: review.TypeMeta, Response: response})
+}
+
+// admitRobot returns why the Robot of request is denied, if it is. Rules are
+// checked against the Robot as it is, policies with the defaults of its
+// RobotClass merged in if the class exists. An update leaving the spec as it
+// was is only denied for the violations it introduces, so that Robots
+// admitted before a rule or policy was tightened can still be labeled,
+// annotated or activated.
+func (c *Controller) admitRobot(request *admissionv1.AdmissionRequest) ([]string, error) {
+	if request.Operation != admissionv1.Create && request.Operation != admissionv1.Update {
+		return nil, nil
+	}
+
+	robot, err := decodeRobot(request.Object.Raw, request.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	ruleViolations, violations, err := c.admissionViolations(robot)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Operation == admissionv1.Update {
+		old, err := decodeRobot(request.OldObject.Raw, request.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		if equality.Semantic.DeepEqual(old.Spec, robot.Spec) {
+			oldRuleViolations, oldViolations, err := c.admissionViolations(old)
+			if err != nil {
+				return nil, err
+			}
+			ruleViolations = newRuleViolations(ruleViolations, oldRuleViolations)
+			violations = newViolations(violations, oldViolations)
+		}
+	}
+
+	var denials []string
+	if len(ruleViolations) > 0 {
+		countRuleDenials(ruleViolations, admissionSource)
+		denials = append(denials, fmt.Sprintf(MessageRuleViolation, strings.Join(ruleMessages(ruleViolations), "; ")))
+	}
+	if len(violations) > 0 {
+		denials = append(denials, fmt.Sprintf(MessagePolicyViolation, strings.Join(violations, "; ")))
+	}
+
+	return denials, nil
+}
+
+// admissionViolations returns the rules robot fails and how it violates its
+// policies.
+func (c *Controller) admissionViolations(robot *robotv1.Robot) ([]ruleViolation, []string, error) {
+	ruleViolations, err := c.evaluateRules(robot)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if robot.Spec.ClassName != "" {
+		class, err := c.robotClassesLister.Get(robot.Spec.ClassName)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, nil, err
+		}
+		if class != nil {
+			robot = mergeClass(robot, class)
+		}
+	}
+
+	violations, err := c.policyViolations(robot)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ruleViolations, violations, nil
+}
+
+// decodeRobot decodes the Robot in raw, defaulting its namespace.
+func decodeRobot(raw []byte, namespace string) (*robotv1.Robot, error) {
+	robot := &robotv1.Robot{}
+	if err := json.Unmarshal(raw, robot); err != nil {
+		return nil, err
+	}
+	if robot.Namespace == "" {
+		robot.Namespace = namespace
+	}
+
+	return robot, nil
+}
+
+// newRuleViolations returns the violations not in old.
+func newRuleViolations(violations, old []ruleViolation) []ruleViolation {
+	var added []ruleViolation
+	for _, violation := range violations {
+		found := false
+		for _, o := range old {
+			if o == violation {
+				found = true
+				break
+			}
+		}
+		if !found {
+			added = append(added, violation)
+		}
+	}
+
+	return added
+}
+
+// newViolations returns the violations not in old.
+func newViolations(violations, old []string) []string {
+	var added []string
+	for _, violation := range violations {
+		if !contains(old, violation) {
+			added = append(added, violation)
+		}
+	}
+
+	return added
+}
@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+	robotlisters "robot-operator/pkg/generated/listers/robot/v1"
+)
+
+// newAdmissionRobot returns a Robot of class with replicas and labels.
+func newAdmissionRobot(class string, replicas int32, labels map[string]string) *robotv1.Robot {
+	return &robotv1.Robot{
+		ObjectMeta: metav1.ObjectMeta{Name: "robot", Namespace: metav1.NamespaceDefault, Labels: labels},
+		Spec:       robotv1.RobotSpec{ClassName: class, Replicas: int32Ptr(replicas)},
+	}
+}
+
+func rawRobot(t *testing.T, robot *robotv1.Robot) runtime.RawExtension {
+	if robot == nil {
+		return runtime.RawExtension{}
+	}
+
+	raw, err := json.Marshal(robot)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	return runtime.RawExtension{Raw: raw}
+}
+
+func TestAdmitRobot(t *testing.T) {
+	policies := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	policies.Add(&robotv1.RobotPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "replicas", Namespace: metav1.NamespaceDefault},
+		Spec:       robotv1.RobotPolicySpec{MaxReplicas: int32Ptr(3)},
+	})
+	clusterPolicies := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	clusterPolicies.Add(&robotv1.ClusterRobotPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "registries"},
+		Spec:       robotv1.RobotPolicySpec{AllowedRegistries: []string{"registry.example.com"}},
+	})
+	classes := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	classes.Add(&robotv1.RobotClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "internal"},
+		Spec:       robotv1.RobotClassSpec{ImageRegistry: "registry.example.com"},
+	})
+
+	c := &Controller{
+		robotPoliciesLister:        robotlisters.NewRobotPolicyLister(policies),
+		clusterRobotPoliciesLister: robotlisters.NewClusterRobotPolicyLister(clusterPolicies),
+		robotClassesLister:         robotlisters.NewRobotClassLister(classes),
+	}
+
+	tooMany := fmt.Sprintf(MessagePolicyViolation, `RobotPolicy "replicas": replicas 5 exceed 3`)
+	unregistered := fmt.Sprintf(MessagePolicyViolation, `ClusterRobotPolicy "registries": image "nginx:latest" of container "nginx" is not from an allowed registry`)
+	tests := []struct {
+		name      string
+		operation admissionv1.Operation
+		robot     *robotv1.Robot
+		old       *robotv1.Robot
+		want      []string
+	}{
+		{
+			name:      "create within the policies",
+			operation: admissionv1.Create,
+			robot:     newAdmissionRobot("internal", 3, nil),
+		},
+		{
+			name:      "create violating a policy",
+			operation: admissionv1.Create,
+			robot:     newAdmissionRobot("internal", 5, nil),
+			want:      []string{tooMany},
+		},
+		{
+			name:      "the class is merged in",
+			operation: admissionv1.Create,
+			robot:     newAdmissionRobot("", 3, nil),
+			want:      []string{unregistered},
+		},
+		{
+			name:      "unknown class",
+			operation: admissionv1.Create,
+			robot:     newAdmissionRobot("missing", 3, nil),
+			want:      []string{unregistered},
+		},
+		{
+			name:      "update leaving the spec as it was",
+			operation: admissionv1.Update,
+			robot:     newAdmissionRobot("internal", 5, map[string]string{"team": "robots"}),
+			old:       newAdmissionRobot("internal", 5, nil),
+		},
+		{
+			name:      "update changing the spec",
+			operation: admissionv1.Update,
+			robot:     newAdmissionRobot("internal", 5, nil),
+			old:       newAdmissionRobot("internal", 4, nil),
+			want:      []string{tooMany},
+		},
+		{
+			name:      "delete",
+			operation: admissionv1.Delete,
+			old:       newAdmissionRobot("internal", 5, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := &admissionv1.AdmissionRequest{
+				Operation: tt.operation,
+				Namespace: metav1.NamespaceDefault,
+				Object:    rawRobot(t, tt.robot),
+				OldObject: rawRobot(t, tt.old),
+			}
+
+			got, err := c.admitRobot(request)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	v1 "robot-operator/pkg/apis/robot/v1"
+	scheme "robot-operator/pkg/generated/clientset/versioned/scheme"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ClusterRobotPoliciesGetter has a method to return a ClusterRobotPolicyInterface.
+// A group's client should implement this interface.
+type ClusterRobotPoliciesGetter interface {
+	ClusterRobotPolicies() ClusterRobotPolicyInterface
+}
+
+// ClusterRobotPolicyInterface has methods to work with ClusterRobotPolicy resources.
+type ClusterRobotPolicyInterface interface {
+	Create(ctx context.Context, clusterRobotPolicy *v1.ClusterRobotPolicy, opts metav1.CreateOptions) (*v1.ClusterRobotPolicy, error)
+	Update(ctx context.Context, clusterRobotPolicy *v1.ClusterRobotPolicy, opts metav1.UpdateOptions) (*v1.ClusterRobotPolicy, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.ClusterRobotPolicy, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.ClusterRobotPolicyList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.ClusterRobotPolicy, err error)
+	ClusterRobotPolicyExpansion
+}
+
+// clusterRobotPolicies implements ClusterRobotPolicyInterface
+type clusterRobotPolicies struct {
+	client rest.Interface
+}
+
+// newClusterRobotPolicies returns a ClusterRobotPolicies
+func newClusterRobotPolicies(c *RobotV1Client) *clusterRobotPolicies {
+	return &clusterRobotPolicies{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the clusterRobotPolicy, and returns the corresponding clusterRobotPolicy object, and an error if there is any.
+func (c *clusterRobotPolicies) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.ClusterRobotPolicy, err error) {
+	result = &v1.ClusterRobotPolicy{}
+	err = c.client.Get().
+		Resource("clusterrobotpolicies").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ClusterRobotPolicies that match those selectors.
+func (c *clusterRobotPolicies) List(ctx context.Context, opts metav1.ListOptions) (result *v1.ClusterRobotPolicyList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.ClusterRobotPolicyList{}
+	err = c.client.Get().
+		Resource("clusterrobotpolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested clusterRobotPolicies.
+func (c *clusterRobotPolicies) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("clusterrobotpolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a clusterRobotPolicy and creates it.  Returns the server's representation of the clusterRobotPolicy, and an error, if there is any.
+func (c *clusterRobotPolicies) Create(ctx context.Context, clusterRobotPolicy *v1.ClusterRobotPolicy, opts metav1.CreateOptions) (result *v1.ClusterRobotPolicy, err error) {
+	result = &v1.ClusterRobotPolicy{}
+	err = c.client.Post().
+		Resource("clusterrobotpolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterRobotPolicy).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a clusterRobotPolicy and updates it. Returns the server's representation of the clusterRobotPolicy, and an error, if there is any.
+func (c *clusterRobotPolicies) Update(ctx context.Context, clusterRobotPolicy *v1.ClusterRobotPolicy, opts metav1.UpdateOptions) (result *v1.ClusterRobotPolicy, err error) {
+	result = &v1.ClusterRobotPolicy{}
+	err = c.client.Put().
+		Resource("clusterrobotpolicies").
+		Name(clusterRobotPolicy.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterRobotPolicy).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the clusterRobotPolicy and deletes it. Returns an error if one occurs.
+func (c *clusterRobotPolicies) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("clusterrobotpolicies").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *clusterRobotPolicies) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("clusterrobotpolicies").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched clusterRobotPolicy.
+func (c *clusterRobotPolicies) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.ClusterRobotPolicy, err error) {
+	result = &v1.ClusterRobotPolicy{}
+	err = c.client.Patch(pt).
+		Resource("clusterrobotpolicies").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,122 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeClusterRobotPolicies implements ClusterRobotPolicyInterface
+type FakeClusterRobotPolicies struct {
+	Fake *FakeRobotV1
+}
+
+var clusterrobotpoliciesResource = schema.GroupVersionResource{Group: "robot.llleon.io", Version: "v1", Resource: "clusterrobotpolicies"}
+
+var clusterrobotpoliciesKind = schema.GroupVersionKind{Group: "robot.llleon.io", Version: "v1", Kind: "ClusterRobotPolicy"}
+
+// Get takes name of the clusterRobotPolicy, and returns the corresponding clusterRobotPolicy object, and an error if there is any.
+func (c *FakeClusterRobotPolicies) Get(ctx context.Context, name string, options v1.GetOptions) (result *robotv1.ClusterRobotPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(clusterrobotpoliciesResource, name), &robotv1.ClusterRobotPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*robotv1.ClusterRobotPolicy), err
+}
+
+// List takes label and field selectors, and returns the list of ClusterRobotPolicies that match those selectors.
+func (c *FakeClusterRobotPolicies) List(ctx context.Context, opts v1.ListOptions) (result *robotv1.ClusterRobotPolicyList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(clusterrobotpoliciesResource, clusterrobotpoliciesKind, opts), &robotv1.ClusterRobotPolicyList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &robotv1.ClusterRobotPolicyList{ListMeta: obj.(*robotv1.ClusterRobotPolicyList).ListMeta}
+	for _, item := range obj.(*robotv1.ClusterRobotPolicyList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested clusterRobotPolicies.
+func (c *FakeClusterRobotPolicies) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(clusterrobotpoliciesResource, opts))
+}
+
+// Create takes the representation of a clusterRobotPolicy and creates it.  Returns the server's representation of the clusterRobotPolicy, and an error, if there is any.
+func (c *FakeClusterRobotPolicies) Create(ctx context.Context, clusterRobotPolicy *robotv1.ClusterRobotPolicy, opts v1.CreateOptions) (result *robotv1.ClusterRobotPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(clusterrobotpoliciesResource, clusterRobotPolicy), &robotv1.ClusterRobotPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*robotv1.ClusterRobotPolicy), err
+}
+
+// Update takes the representation of a clusterRobotPolicy and updates it. Returns the server's representation of the clusterRobotPolicy, and an error, if there is any.
+func (c *FakeClusterRobotPolicies) Update(ctx context.Context, clusterRobotPolicy *robotv1.ClusterRobotPolicy, opts v1.UpdateOptions) (result *robotv1.ClusterRobotPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(clusterrobotpoliciesResource, clusterRobotPolicy), &robotv1.ClusterRobotPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*robotv1.ClusterRobotPolicy), err
+}
+
+// Delete takes name of the clusterRobotPolicy and deletes it. Returns an error if one occurs.
+func (c *FakeClusterRobotPolicies) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteAction(clusterrobotpoliciesResource, name), &robotv1.ClusterRobotPolicy{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeClusterRobotPolicies) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(clusterrobotpoliciesResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &robotv1.ClusterRobotPolicyList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched clusterRobotPolicy.
+func (c *FakeClusterRobotPolicies) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *robotv1.ClusterRobotPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clusterrobotpoliciesResource, name, pt, data, subresources...), &robotv1.ClusterRobotPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*robotv1.ClusterRobotPolicy), err
+}
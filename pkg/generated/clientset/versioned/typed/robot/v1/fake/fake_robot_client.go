@@ -29,10 +29,38 @@ type FakeRobotV1 struct {
 	*testing.Fake
 }
 
+func (c *FakeRobotV1) ClusterRobotPolicies() v1.ClusterRobotPolicyInterface {
+	return &FakeClusterRobotPolicies{c}
+}
+
 func (c *FakeRobotV1) Robots(namespace string) v1.RobotInterface {
 	return &FakeRobots{c, namespace}
 }
 
+func (c *FakeRobotV1) RobotClasses() v1.RobotClassInterface {
+	return &FakeRobotClasses{c}
+}
+
+func (c *FakeRobotV1) RobotCronTasks(namespace string) v1.RobotCronTaskInterface {
+	return &FakeRobotCronTasks{c, namespace}
+}
+
+func (c *FakeRobotV1) RobotPolicies(namespace string) v1.RobotPolicyInterface {
+	return &FakeRobotPolicies{c, namespace}
+}
+
+func (c *FakeRobotV1) RobotQuotas(namespace string) v1.RobotQuotaInterface {
+	return &FakeRobotQuotas{c, namespace}
+}
+
+func (c *FakeRobotV1) RobotSets(namespace string) v1.RobotSetInterface {
+	return &FakeRobotSets{c, namespace}
+}
+
+func (c *FakeRobotV1) RobotTasks(namespace string) v1.RobotTaskInterface {
+	return &FakeRobotTasks{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeRobotV1) RESTClient() rest.Interface {
@@ -0,0 +1,122 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeRobotClasses implements RobotClassInterface
+type FakeRobotClasses struct {
+	Fake *FakeRobotV1
+}
+
+var robotclassesResource = schema.GroupVersionResource{Group: "robot.llleon.io", Version: "v1", Resource: "robotclasses"}
+
+var robotclassesKind = schema.GroupVersionKind{Group: "robot.llleon.io", Version: "v1", Kind: "RobotClass"}
+
+// Get takes name of the robotClass, and returns the corresponding robotClass object, and an error if there is any.
+func (c *FakeRobotClasses) Get(ctx context.Context, name string, options v1.GetOptions) (result *robotv1.RobotClass, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(robotclassesResource, name), &robotv1.RobotClass{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*robotv1.RobotClass), err
+}
+
+// List takes label and field selectors, and returns the list of RobotClasses that match those selectors.
+func (c *FakeRobotClasses) List(ctx context.Context, opts v1.ListOptions) (result *robotv1.RobotClassList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(robotclassesResource, robotclassesKind, opts), &robotv1.RobotClassList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &robotv1.RobotClassList{ListMeta: obj.(*robotv1.RobotClassList).ListMeta}
+	for _, item := range obj.(*robotv1.RobotClassList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested robotClasses.
+func (c *FakeRobotClasses) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(robotclassesResource, opts))
+}
+
+// Create takes the representation of a robotClass and creates it.  Returns the server's representation of the robotClass, and an error, if there is any.
+func (c *FakeRobotClasses) Create(ctx context.Context, robotClass *robotv1.RobotClass, opts v1.CreateOptions) (result *robotv1.RobotClass, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(robotclassesResource, robotClass), &robotv1.RobotClass{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*robotv1.RobotClass), err
+}
+
+// Update takes the representation of a robotClass and updates it. Returns the server's representation of the robotClass, and an error, if there is any.
+func (c *FakeRobotClasses) Update(ctx context.Context, robotClass *robotv1.RobotClass, opts v1.UpdateOptions) (result *robotv1.RobotClass, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(robotclassesResource, robotClass), &robotv1.RobotClass{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*robotv1.RobotClass), err
+}
+
+// Delete takes name of the robotClass and deletes it. Returns an error if one occurs.
+func (c *FakeRobotClasses) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteAction(robotclassesResource, name), &robotv1.RobotClass{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeRobotClasses) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(robotclassesResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &robotv1.RobotClassList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched robotClass.
+func (c *FakeRobotClasses) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *robotv1.RobotClass, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(robotclassesResource, name, pt, data, subresources...), &robotv1.RobotClass{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*robotv1.RobotClass), err
+}
@@ -0,0 +1,130 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeRobotPolicies implements RobotPolicyInterface
+type FakeRobotPolicies struct {
+	Fake *FakeRobotV1
+	ns   string
+}
+
+var robotpoliciesResource = schema.GroupVersionResource{Group: "robot.llleon.io", Version: "v1", Resource: "robotpolicies"}
+
+var robotpoliciesKind = schema.GroupVersionKind{Group: "robot.llleon.io", Version: "v1", Kind: "RobotPolicy"}
+
+// Get takes name of the robotPolicy, and returns the corresponding robotPolicy object, and an error if there is any.
+func (c *FakeRobotPolicies) Get(ctx context.Context, name string, options v1.GetOptions) (result *robotv1.RobotPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(robotpoliciesResource, c.ns, name), &robotv1.RobotPolicy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*robotv1.RobotPolicy), err
+}
+
+// List takes label and field selectors, and returns the list of RobotPolicies that match those selectors.
+func (c *FakeRobotPolicies) List(ctx context.Context, opts v1.ListOptions) (result *robotv1.RobotPolicyList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(robotpoliciesResource, robotpoliciesKind, c.ns, opts), &robotv1.RobotPolicyList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &robotv1.RobotPolicyList{ListMeta: obj.(*robotv1.RobotPolicyList).ListMeta}
+	for _, item := range obj.(*robotv1.RobotPolicyList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested robotPolicies.
+func (c *FakeRobotPolicies) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(robotpoliciesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a robotPolicy and creates it.  Returns the server's representation of the robotPolicy, and an error, if there is any.
+func (c *FakeRobotPolicies) Create(ctx context.Context, robotPolicy *robotv1.RobotPolicy, opts v1.CreateOptions) (result *robotv1.RobotPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(robotpoliciesResource, c.ns, robotPolicy), &robotv1.RobotPolicy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*robotv1.RobotPolicy), err
+}
+
+// Update takes the representation of a robotPolicy and updates it. Returns the server's representation of the robotPolicy, and an error, if there is any.
+func (c *FakeRobotPolicies) Update(ctx context.Context, robotPolicy *robotv1.RobotPolicy, opts v1.UpdateOptions) (result *robotv1.RobotPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(robotpoliciesResource, c.ns, robotPolicy), &robotv1.RobotPolicy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*robotv1.RobotPolicy), err
+}
+
+// Delete takes name of the robotPolicy and deletes it. Returns an error if one occurs.
+func (c *FakeRobotPolicies) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(robotpoliciesResource, c.ns, name), &robotv1.RobotPolicy{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeRobotPolicies) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(robotpoliciesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &robotv1.RobotPolicyList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched robotPolicy.
+func (c *FakeRobotPolicies) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *robotv1.RobotPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(robotpoliciesResource, c.ns, name, pt, data, subresources...), &robotv1.RobotPolicy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*robotv1.RobotPolicy), err
+}
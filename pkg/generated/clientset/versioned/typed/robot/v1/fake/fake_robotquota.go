@@ -0,0 +1,142 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeRobotQuotas implements RobotQuotaInterface
+type FakeRobotQuotas struct {
+	Fake *FakeRobotV1
+	ns   string
+}
+
+var robotquotasResource = schema.GroupVersionResource{Group: "robot.llleon.io", Version: "v1", Resource: "robotquotas"}
+
+var robotquotasKind = schema.GroupVersionKind{Group: "robot.llleon.io", Version: "v1", Kind: "RobotQuota"}
+
+// Get takes name of the robotQuota, and returns the corresponding robotQuota object, and an error if there is any.
+func (c *FakeRobotQuotas) Get(ctx context.Context, name string, options v1.GetOptions) (result *robotv1.RobotQuota, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(robotquotasResource, c.ns, name), &robotv1.RobotQuota{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*robotv1.RobotQuota), err
+}
+
+// List takes label and field selectors, and returns the list of RobotQuotas that match those selectors.
+func (c *FakeRobotQuotas) List(ctx context.Context, opts v1.ListOptions) (result *robotv1.RobotQuotaList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(robotquotasResource, robotquotasKind, c.ns, opts), &robotv1.RobotQuotaList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &robotv1.RobotQuotaList{ListMeta: obj.(*robotv1.RobotQuotaList).ListMeta}
+	for _, item := range obj.(*robotv1.RobotQuotaList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested robotQuotas.
+func (c *FakeRobotQuotas) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(robotquotasResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a robotQuota and creates it.  Returns the server's representation of the robotQuota, and an error, if there is any.
+func (c *FakeRobotQuotas) Create(ctx context.Context, robotQuota *robotv1.RobotQuota, opts v1.CreateOptions) (result *robotv1.RobotQuota, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(robotquotasResource, c.ns, robotQuota), &robotv1.RobotQuota{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*robotv1.RobotQuota), err
+}
+
+// Update takes the representation of a robotQuota and updates it. Returns the server's representation of the robotQuota, and an error, if there is any.
+func (c *FakeRobotQuotas) Update(ctx context.Context, robotQuota *robotv1.RobotQuota, opts v1.UpdateOptions) (result *robotv1.RobotQuota, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(robotquotasResource, c.ns, robotQuota), &robotv1.RobotQuota{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*robotv1.RobotQuota), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeRobotQuotas) UpdateStatus(ctx context.Context, robotQuota *robotv1.RobotQuota, opts v1.UpdateOptions) (*robotv1.RobotQuota, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(robotquotasResource, "status", c.ns, robotQuota), &robotv1.RobotQuota{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*robotv1.RobotQuota), err
+}
+
+// Delete takes name of the robotQuota and deletes it. Returns an error if one occurs.
+func (c *FakeRobotQuotas) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(robotquotasResource, c.ns, name), &robotv1.RobotQuota{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeRobotQuotas) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(robotquotasResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &robotv1.RobotQuotaList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched robotQuota.
+func (c *FakeRobotQuotas) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *robotv1.RobotQuota, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(robotquotasResource, c.ns, name, pt, data, subresources...), &robotv1.RobotQuota{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*robotv1.RobotQuota), err
+}
@@ -0,0 +1,142 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	robotv1 "robot-operator/pkg/apis/robot/v1"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeRobotSets implements RobotSetInterface
+type FakeRobotSets struct {
+	Fake *FakeRobotV1
+	ns   string
+}
+
+var robotsetsResource = schema.GroupVersionResource{Group: "robot.llleon.io", Version: "v1", Resource: "robotsets"}
+
+var robotsetsKind = schema.GroupVersionKind{Group: "robot.llleon.io", Version: "v1", Kind: "RobotSet"}
+
+// Get takes name of the robotSet, and returns the corresponding robotSet object, and an error if there is any.
+func (c *FakeRobotSets) Get(ctx context.Context, name string, options v1.GetOptions) (result *robotv1.RobotSet, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(robotsetsResource, c.ns, name), &robotv1.RobotSet{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*robotv1.RobotSet), err
+}
+
+// List takes label and field selectors, and returns the list of RobotSets that match those selectors.
+func (c *FakeRobotSets) List(ctx context.Context, opts v1.ListOptions) (result *robotv1.RobotSetList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(robotsetsResource, robotsetsKind, c.ns, opts), &robotv1.RobotSetList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &robotv1.RobotSetList{ListMeta: obj.(*robotv1.RobotSetList).ListMeta}
+	for _, item := range obj.(*robotv1.RobotSetList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested robotSets.
+func (c *FakeRobotSets) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(robotsetsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a robotSet and creates it.  Returns the server's representation of the robotSet, and an error, if there is any.
+func (c *FakeRobotSets) Create(ctx context.Context, robotSet *robotv1.RobotSet, opts v1.CreateOptions) (result *robotv1.RobotSet, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(robotsetsResource, c.ns, robotSet), &robotv1.RobotSet{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*robotv1.RobotSet), err
+}
+
+// Update takes the representation of a robotSet and updates it. Returns the server's representation of the robotSet, and an error, if there is any.
+func (c *FakeRobotSets) Update(ctx context.Context, robotSet *robotv1.RobotSet, opts v1.UpdateOptions) (result *robotv1.RobotSet, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(robotsetsResource, c.ns, robotSet), &robotv1.RobotSet{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*robotv1.RobotSet), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeRobotSets) UpdateStatus(ctx context.Context, robotSet *robotv1.RobotSet, opts v1.UpdateOptions) (*robotv1.RobotSet, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(robotsetsResource, "status", c.ns, robotSet), &robotv1.RobotSet{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*robotv1.RobotSet), err
+}
+
+// Delete takes name of the robotSet and deletes it. Returns an error if one occurs.
+func (c *FakeRobotSets) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(robotsetsResource, c.ns, name), &robotv1.RobotSet{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeRobotSets) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(robotsetsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &robotv1.RobotSetList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched robotSet.
+func (c *FakeRobotSets) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *robotv1.RobotSet, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(robotsetsResource, c.ns, name, pt, data, subresources...), &robotv1.RobotSet{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*robotv1.RobotSet), err
+}
@@ -18,4 +18,18 @@ limitations under the License.
 
 package v1
 
+type ClusterRobotPolicyExpansion interface{}
+
 type RobotExpansion interface{}
+
+type RobotClassExpansion interface{}
+
+type RobotCronTaskExpansion interface{}
+
+type RobotPolicyExpansion interface{}
+
+type RobotQuotaExpansion interface{}
+
+type RobotSetExpansion interface{}
+
+type RobotTaskExpansion interface{}
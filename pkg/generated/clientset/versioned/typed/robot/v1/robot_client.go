@@ -27,7 +27,14 @@ import (
 
 type RobotV1Interface interface {
 	RESTClient() rest.Interface
+	ClusterRobotPoliciesGetter
 	RobotsGetter
+	RobotClassesGetter
+	RobotCronTasksGetter
+	RobotPoliciesGetter
+	RobotQuotasGetter
+	RobotSetsGetter
+	RobotTasksGetter
 }
 
 // RobotV1Client is used to interact with features provided by the robot.llleon.io group.
@@ -35,10 +42,38 @@ type RobotV1Client struct {
 	restClient rest.Interface
 }
 
+func (c *RobotV1Client) ClusterRobotPolicies() ClusterRobotPolicyInterface {
+	return newClusterRobotPolicies(c)
+}
+
 func (c *RobotV1Client) Robots(namespace string) RobotInterface {
 	return newRobots(c, namespace)
 }
 
+func (c *RobotV1Client) RobotClasses() RobotClassInterface {
+	return newRobotClasses(c)
+}
+
+func (c *RobotV1Client) RobotCronTasks(namespace string) RobotCronTaskInterface {
+	return newRobotCronTasks(c, namespace)
+}
+
+func (c *RobotV1Client) RobotPolicies(namespace string) RobotPolicyInterface {
+	return newRobotPolicies(c, namespace)
+}
+
+func (c *RobotV1Client) RobotQuotas(namespace string) RobotQuotaInterface {
+	return newRobotQuotas(c, namespace)
+}
+
+func (c *RobotV1Client) RobotSets(namespace string) RobotSetInterface {
+	return newRobotSets(c, namespace)
+}
+
+func (c *RobotV1Client) RobotTasks(namespace string) RobotTaskInterface {
+	return newRobotTasks(c, namespace)
+}
+
 // NewForConfig creates a new RobotV1Client for the given config.
 func NewForConfig(c *rest.Config) (*RobotV1Client, error) {
 	config := *c
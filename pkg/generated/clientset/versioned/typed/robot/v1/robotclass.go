@@ -0,0 +1,168 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	v1 "robot-operator/pkg/apis/robot/v1"
+	scheme "robot-operator/pkg/generated/clientset/versioned/scheme"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// RobotClassesGetter has a method to return a RobotClassInterface.
+// A group's client should implement this interface.
+type RobotClassesGetter interface {
+	RobotClasses() RobotClassInterface
+}
+
+// RobotClassInterface has methods to work with RobotClass resources.
+type RobotClassInterface interface {
+	Create(ctx context.Context, robotClass *v1.RobotClass, opts metav1.CreateOptions) (*v1.RobotClass, error)
+	Update(ctx context.Context, robotClass *v1.RobotClass, opts metav1.UpdateOptions) (*v1.RobotClass, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.RobotClass, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.RobotClassList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.RobotClass, err error)
+	RobotClassExpansion
+}
+
+// robotClasses implements RobotClassInterface
+type robotClasses struct {
+	client rest.Interface
+}
+
+// newRobotClasses returns a RobotClasses
+func newRobotClasses(c *RobotV1Client) *robotClasses {
+	return &robotClasses{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the robotClass, and returns the corresponding robotClass object, and an error if there is any.
+func (c *robotClasses) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.RobotClass, err error) {
+	result = &v1.RobotClass{}
+	err = c.client.Get().
+		Resource("robotclasses").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of RobotClasses that match those selectors.
+func (c *robotClasses) List(ctx context.Context, opts metav1.ListOptions) (result *v1.RobotClassList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.RobotClassList{}
+	err = c.client.Get().
+		Resource("robotclasses").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested robotClasses.
+func (c *robotClasses) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("robotclasses").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a robotClass and creates it.  Returns the server's representation of the robotClass, and an error, if there is any.
+func (c *robotClasses) Create(ctx context.Context, robotClass *v1.RobotClass, opts metav1.CreateOptions) (result *v1.RobotClass, err error) {
+	result = &v1.RobotClass{}
+	err = c.client.Post().
+		Resource("robotclasses").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(robotClass).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a robotClass and updates it. Returns the server's representation of the robotClass, and an error, if there is any.
+func (c *robotClasses) Update(ctx context.Context, robotClass *v1.RobotClass, opts metav1.UpdateOptions) (result *v1.RobotClass, err error) {
+	result = &v1.RobotClass{}
+	err = c.client.Put().
+		Resource("robotclasses").
+		Name(robotClass.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(robotClass).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the robotClass and deletes it. Returns an error if one occurs.
+func (c *robotClasses) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("robotclasses").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *robotClasses) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("robotclasses").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched robotClass.
+func (c *robotClasses) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.RobotClass, err error) {
+	result = &v1.RobotClass{}
+	err = c.client.Patch(pt).
+		Resource("robotclasses").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
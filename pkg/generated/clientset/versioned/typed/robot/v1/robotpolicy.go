@@ -0,0 +1,178 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	v1 "robot-operator/pkg/apis/robot/v1"
+	scheme "robot-operator/pkg/generated/clientset/versioned/scheme"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// RobotPoliciesGetter has a method to return a RobotPolicyInterface.
+// A group's client should implement this interface.
+type RobotPoliciesGetter interface {
+	RobotPolicies(namespace string) RobotPolicyInterface
+}
+
+// RobotPolicyInterface has methods to work with RobotPolicy resources.
+type RobotPolicyInterface interface {
+	Create(ctx context.Context, robotPolicy *v1.RobotPolicy, opts metav1.CreateOptions) (*v1.RobotPolicy, error)
+	Update(ctx context.Context, robotPolicy *v1.RobotPolicy, opts metav1.UpdateOptions) (*v1.RobotPolicy, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.RobotPolicy, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.RobotPolicyList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.RobotPolicy, err error)
+	RobotPolicyExpansion
+}
+
+// robotPolicies implements RobotPolicyInterface
+type robotPolicies struct {
+	client rest.Interface
+	ns     string
+}
+
+// newRobotPolicies returns a RobotPolicies
+func newRobotPolicies(c *RobotV1Client, namespace string) *robotPolicies {
+	return &robotPolicies{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the robotPolicy, and returns the corresponding robotPolicy object, and an error if there is any.
+func (c *robotPolicies) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.RobotPolicy, err error) {
+	result = &v1.RobotPolicy{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("robotpolicies").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of RobotPolicies that match those selectors.
+func (c *robotPolicies) List(ctx context.Context, opts metav1.ListOptions) (result *v1.RobotPolicyList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.RobotPolicyList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("robotpolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested robotPolicies.
+func (c *robotPolicies) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("robotpolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a robotPolicy and creates it.  Returns the server's representation of the robotPolicy, and an error, if there is any.
+func (c *robotPolicies) Create(ctx context.Context, robotPolicy *v1.RobotPolicy, opts metav1.CreateOptions) (result *v1.RobotPolicy, err error) {
+	result = &v1.RobotPolicy{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("robotpolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(robotPolicy).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a robotPolicy and updates it. Returns the server's representation of the robotPolicy, and an error, if there is any.
+func (c *robotPolicies) Update(ctx context.Context, robotPolicy *v1.RobotPolicy, opts metav1.UpdateOptions) (result *v1.RobotPolicy, err error) {
+	result = &v1.RobotPolicy{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("robotpolicies").
+		Name(robotPolicy.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(robotPolicy).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the robotPolicy and deletes it. Returns an error if one occurs.
+func (c *robotPolicies) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("robotpolicies").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *robotPolicies) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("robotpolicies").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched robotPolicy.
+func (c *robotPolicies) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.RobotPolicy, err error) {
+	result = &v1.RobotPolicy{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("robotpolicies").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,195 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	v1 "robot-operator/pkg/apis/robot/v1"
+	scheme "robot-operator/pkg/generated/clientset/versioned/scheme"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// RobotQuotasGetter has a method to return a RobotQuotaInterface.
+// A group's client should implement this interface.
+type RobotQuotasGetter interface {
+	RobotQuotas(namespace string) RobotQuotaInterface
+}
+
+// RobotQuotaInterface has methods to work with RobotQuota resources.
+type RobotQuotaInterface interface {
+	Create(ctx context.Context, robotQuota *v1.RobotQuota, opts metav1.CreateOptions) (*v1.RobotQuota, error)
+	Update(ctx context.Context, robotQuota *v1.RobotQuota, opts metav1.UpdateOptions) (*v1.RobotQuota, error)
+	UpdateStatus(ctx context.Context, robotQuota *v1.RobotQuota, opts metav1.UpdateOptions) (*v1.RobotQuota, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.RobotQuota, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.RobotQuotaList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.RobotQuota, err error)
+	RobotQuotaExpansion
+}
+
+// robotQuotas implements RobotQuotaInterface
+type robotQuotas struct {
+	client rest.Interface
+	ns     string
+}
+
+// newRobotQuotas returns a RobotQuotas
+func newRobotQuotas(c *RobotV1Client, namespace string) *robotQuotas {
+	return &robotQuotas{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the robotQuota, and returns the corresponding robotQuota object, and an error if there is any.
+func (c *robotQuotas) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.RobotQuota, err error) {
+	result = &v1.RobotQuota{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("robotquotas").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of RobotQuotas that match those selectors.
+func (c *robotQuotas) List(ctx context.Context, opts metav1.ListOptions) (result *v1.RobotQuotaList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.RobotQuotaList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("robotquotas").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested robotQuotas.
+func (c *robotQuotas) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("robotquotas").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a robotQuota and creates it.  Returns the server's representation of the robotQuota, and an error, if there is any.
+func (c *robotQuotas) Create(ctx context.Context, robotQuota *v1.RobotQuota, opts metav1.CreateOptions) (result *v1.RobotQuota, err error) {
+	result = &v1.RobotQuota{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("robotquotas").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(robotQuota).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a robotQuota and updates it. Returns the server's representation of the robotQuota, and an error, if there is any.
+func (c *robotQuotas) Update(ctx context.Context, robotQuota *v1.RobotQuota, opts metav1.UpdateOptions) (result *v1.RobotQuota, err error) {
+	result = &v1.RobotQuota{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("robotquotas").
+		Name(robotQuota.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(robotQuota).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *robotQuotas) UpdateStatus(ctx context.Context, robotQuota *v1.RobotQuota, opts metav1.UpdateOptions) (result *v1.RobotQuota, err error) {
+	result = &v1.RobotQuota{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("robotquotas").
+		Name(robotQuota.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(robotQuota).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the robotQuota and deletes it. Returns an error if one occurs.
+func (c *robotQuotas) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("robotquotas").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *robotQuotas) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("robotquotas").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched robotQuota.
+func (c *robotQuotas) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.RobotQuota, err error) {
+	result = &v1.RobotQuota{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("robotquotas").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,195 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	v1 "robot-operator/pkg/apis/robot/v1"
+	scheme "robot-operator/pkg/generated/clientset/versioned/scheme"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// RobotSetsGetter has a method to return a RobotSetInterface.
+// A group's client should implement this interface.
+type RobotSetsGetter interface {
+	RobotSets(namespace string) RobotSetInterface
+}
+
+// RobotSetInterface has methods to work with RobotSet resources.
+type RobotSetInterface interface {
+	Create(ctx context.Context, robotSet *v1.RobotSet, opts metav1.CreateOptions) (*v1.RobotSet, error)
+	Update(ctx context.Context, robotSet *v1.RobotSet, opts metav1.UpdateOptions) (*v1.RobotSet, error)
+	UpdateStatus(ctx context.Context, robotSet *v1.RobotSet, opts metav1.UpdateOptions) (*v1.RobotSet, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.RobotSet, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.RobotSetList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.RobotSet, err error)
+	RobotSetExpansion
+}
+
+// robotSets implements RobotSetInterface
+type robotSets struct {
+	client rest.Interface
+	ns     string
+}
+
+// newRobotSets returns a RobotSets
+func newRobotSets(c *RobotV1Client, namespace string) *robotSets {
+	return &robotSets{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the robotSet, and returns the corresponding robotSet object, and an error if there is any.
+func (c *robotSets) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.RobotSet, err error) {
+	result = &v1.RobotSet{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("robotsets").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of RobotSets that match those selectors.
+func (c *robotSets) List(ctx context.Context, opts metav1.ListOptions) (result *v1.RobotSetList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.RobotSetList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("robotsets").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested robotSets.
+func (c *robotSets) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("robotsets").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a robotSet and creates it.  Returns the server's representation of the robotSet, and an error, if there is any.
+func (c *robotSets) Create(ctx context.Context, robotSet *v1.RobotSet, opts metav1.CreateOptions) (result *v1.RobotSet, err error) {
+	result = &v1.RobotSet{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("robotsets").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(robotSet).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a robotSet and updates it. Returns the server's representation of the robotSet, and an error, if there is any.
+func (c *robotSets) Update(ctx context.Context, robotSet *v1.RobotSet, opts metav1.UpdateOptions) (result *v1.RobotSet, err error) {
+	result = &v1.RobotSet{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("robotsets").
+		Name(robotSet.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(robotSet).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *robotSets) UpdateStatus(ctx context.Context, robotSet *v1.RobotSet, opts metav1.UpdateOptions) (result *v1.RobotSet, err error) {
+	result = &v1.RobotSet{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("robotsets").
+		Name(robotSet.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(robotSet).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the robotSet and deletes it. Returns an error if one occurs.
+func (c *robotSets) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("robotsets").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *robotSets) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("robotsets").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched robotSet.
+func (c *robotSets) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.RobotSet, err error) {
+	result = &v1.RobotSet{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("robotsets").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
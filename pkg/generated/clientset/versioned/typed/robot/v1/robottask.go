@@ -0,0 +1,195 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	v1 "robot-operator/pkg/apis/robot/v1"
+	scheme "robot-operator/pkg/generated/clientset/versioned/scheme"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// RobotTasksGetter has a method to return a RobotTaskInterface.
+// A group's client should implement this interface.
+type RobotTasksGetter interface {
+	RobotTasks(namespace string) RobotTaskInterface
+}
+
+// RobotTaskInterface has methods to work with RobotTask resources.
+type RobotTaskInterface interface {
+	Create(ctx context.Context, robotTask *v1.RobotTask, opts metav1.CreateOptions) (*v1.RobotTask, error)
+	Update(ctx context.Context, robotTask *v1.RobotTask, opts metav1.UpdateOptions) (*v1.RobotTask, error)
+	UpdateStatus(ctx context.Context, robotTask *v1.RobotTask, opts metav1.UpdateOptions) (*v1.RobotTask, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.RobotTask, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.RobotTaskList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.RobotTask, err error)
+	RobotTaskExpansion
+}
+
+// robotTasks implements RobotTaskInterface
+type robotTasks struct {
+	client rest.Interface
+	ns     string
+}
+
+// newRobotTasks returns a RobotTasks
+func newRobotTasks(c *RobotV1Client, namespace string) *robotTasks {
+	return &robotTasks{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the robotTask, and returns the corresponding robotTask object, and an error if there is any.
+func (c *robotTasks) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.RobotTask, err error) {
+	result = &v1.RobotTask{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("robottasks").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of RobotTasks that match those selectors.
+func (c *robotTasks) List(ctx context.Context, opts metav1.ListOptions) (result *v1.RobotTaskList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.RobotTaskList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("robottasks").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested robotTasks.
+func (c *robotTasks) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("robottasks").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a robotTask and creates it.  Returns the server's representation of the robotTask, and an error, if there is any.
+func (c *robotTasks) Create(ctx context.Context, robotTask *v1.RobotTask, opts metav1.CreateOptions) (result *v1.RobotTask, err error) {
+	result = &v1.RobotTask{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("robottasks").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(robotTask).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a robotTask and updates it. Returns the server's representation of the robotTask, and an error, if there is any.
+func (c *robotTasks) Update(ctx context.Context, robotTask *v1.RobotTask, opts metav1.UpdateOptions) (result *v1.RobotTask, err error) {
+	result = &v1.RobotTask{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("robottasks").
+		Name(robotTask.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(robotTask).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *robotTasks) UpdateStatus(ctx context.Context, robotTask *v1.RobotTask, opts metav1.UpdateOptions) (result *v1.RobotTask, err error) {
+	result = &v1.RobotTask{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("robottasks").
+		Name(robotTask.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(robotTask).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the robotTask and deletes it. Returns an error if one occurs.
+func (c *robotTasks) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("robottasks").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *robotTasks) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("robottasks").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched robotTask.
+func (c *robotTasks) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.RobotTask, err error) {
+	result = &v1.RobotTask{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("robottasks").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
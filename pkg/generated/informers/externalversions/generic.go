@@ -53,8 +53,22 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=robot.llleon.io, Version=v1
+	case v1.SchemeGroupVersion.WithResource("clusterrobotpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Robot().V1().ClusterRobotPolicies().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("robots"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Robot().V1().Robots().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("robotclasses"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Robot().V1().RobotClasses().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("robotcrontasks"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Robot().V1().RobotCronTasks().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("robotpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Robot().V1().RobotPolicies().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("robotquotas"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Robot().V1().RobotQuotas().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("robotsets"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Robot().V1().RobotSets().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("robottasks"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Robot().V1().RobotTasks().Informer()}, nil
 
 	}
 
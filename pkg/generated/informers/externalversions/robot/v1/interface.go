@@ -24,8 +24,22 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// ClusterRobotPolicies returns a ClusterRobotPolicyInformer.
+	ClusterRobotPolicies() ClusterRobotPolicyInformer
 	// Robots returns a RobotInformer.
 	Robots() RobotInformer
+	// RobotClasses returns a RobotClassInformer.
+	RobotClasses() RobotClassInformer
+	// RobotCronTasks returns a RobotCronTaskInformer.
+	RobotCronTasks() RobotCronTaskInformer
+	// RobotPolicies returns a RobotPolicyInformer.
+	RobotPolicies() RobotPolicyInformer
+	// RobotQuotas returns a RobotQuotaInformer.
+	RobotQuotas() RobotQuotaInformer
+	// RobotSets returns a RobotSetInformer.
+	RobotSets() RobotSetInformer
+	// RobotTasks returns a RobotTaskInformer.
+	RobotTasks() RobotTaskInformer
 }
 
 type version struct {
@@ -39,7 +53,42 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// ClusterRobotPolicies returns a ClusterRobotPolicyInformer.
+func (v *version) ClusterRobotPolicies() ClusterRobotPolicyInformer {
+	return &clusterRobotPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // Robots returns a RobotInformer.
 func (v *version) Robots() RobotInformer {
 	return &robotInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// RobotClasses returns a RobotClassInformer.
+func (v *version) RobotClasses() RobotClassInformer {
+	return &robotClassInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// RobotCronTasks returns a RobotCronTaskInformer.
+func (v *version) RobotCronTasks() RobotCronTaskInformer {
+	return &robotCronTaskInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// RobotPolicies returns a RobotPolicyInformer.
+func (v *version) RobotPolicies() RobotPolicyInformer {
+	return &robotPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// RobotQuotas returns a RobotQuotaInformer.
+func (v *version) RobotQuotas() RobotQuotaInformer {
+	return &robotQuotaInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// RobotSets returns a RobotSetInformer.
+func (v *version) RobotSets() RobotSetInformer {
+	return &robotSetInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// RobotTasks returns a RobotTaskInformer.
+func (v *version) RobotTasks() RobotTaskInformer {
+	return &robotTaskInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
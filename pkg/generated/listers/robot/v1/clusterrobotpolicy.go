@@ -0,0 +1,69 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "robot-operator/pkg/apis/robot/v1"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ClusterRobotPolicyLister helps list ClusterRobotPolicies.
+// All objects returned here must be treated as read-only.
+type ClusterRobotPolicyLister interface {
+	// List lists all ClusterRobotPolicies in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.ClusterRobotPolicy, err error)
+	// Get retrieves the ClusterRobotPolicy from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.ClusterRobotPolicy, error)
+	ClusterRobotPolicyListerExpansion
+}
+
+// clusterRobotPolicyLister implements the ClusterRobotPolicyLister interface.
+type clusterRobotPolicyLister struct {
+	indexer cache.Indexer
+}
+
+// NewClusterRobotPolicyLister returns a new ClusterRobotPolicyLister.
+func NewClusterRobotPolicyLister(indexer cache.Indexer) ClusterRobotPolicyLister {
+	return &clusterRobotPolicyLister{indexer: indexer}
+}
+
+// List lists all ClusterRobotPolicies in the indexer.
+func (s *clusterRobotPolicyLister) List(selector labels.Selector) (ret []*v1.ClusterRobotPolicy, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.ClusterRobotPolicy))
+	})
+	return ret, err
+}
+
+// Get retrieves the ClusterRobotPolicy from the index for a given name.
+func (s *clusterRobotPolicyLister) Get(name string) (*v1.ClusterRobotPolicy, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("clusterrobotpolicy"), name)
+	}
+	return obj.(*v1.ClusterRobotPolicy), nil
+}
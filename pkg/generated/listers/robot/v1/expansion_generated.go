@@ -18,6 +18,10 @@ limitations under the License.
 
 package v1
 
+// ClusterRobotPolicyListerExpansion allows custom methods to be added to
+// ClusterRobotPolicyLister.
+type ClusterRobotPolicyListerExpansion interface{}
+
 // RobotListerExpansion allows custom methods to be added to
 // RobotLister.
 type RobotListerExpansion interface{}
@@ -25,3 +29,47 @@ type RobotListerExpansion interface{}
 // RobotNamespaceListerExpansion allows custom methods to be added to
 // RobotNamespaceLister.
 type RobotNamespaceListerExpansion interface{}
+
+// RobotClassListerExpansion allows custom methods to be added to
+// RobotClassLister.
+type RobotClassListerExpansion interface{}
+
+// RobotCronTaskListerExpansion allows custom methods to be added to
+// RobotCronTaskLister.
+type RobotCronTaskListerExpansion interface{}
+
+// RobotCronTaskNamespaceListerExpansion allows custom methods to be added to
+// RobotCronTaskNamespaceLister.
+type RobotCronTaskNamespaceListerExpansion interface{}
+
+// RobotPolicyListerExpansion allows custom methods to be added to
+// RobotPolicyLister.
+type RobotPolicyListerExpansion interface{}
+
+// RobotPolicyNamespaceListerExpansion allows custom methods to be added to
+// RobotPolicyNamespaceLister.
+type RobotPolicyNamespaceListerExpansion interface{}
+
+// RobotQuotaListerExpansion allows custom methods to be added to
+// RobotQuotaLister.
+type RobotQuotaListerExpansion interface{}
+
+// RobotQuotaNamespaceListerExpansion allows custom methods to be added to
+// RobotQuotaNamespaceLister.
+type RobotQuotaNamespaceListerExpansion interface{}
+
+// RobotSetListerExpansion allows custom methods to be added to
+// RobotSetLister.
+type RobotSetListerExpansion interface{}
+
+// RobotSetNamespaceListerExpansion allows custom methods to be added to
+// RobotSetNamespaceLister.
+type RobotSetNamespaceListerExpansion interface{}
+
+// RobotTaskListerExpansion allows custom methods to be added to
+// RobotTaskLister.
+type RobotTaskListerExpansion interface{}
+
+// RobotTaskNamespaceListerExpansion allows custom methods to be added to
+// RobotTaskNamespaceLister.
+type RobotTaskNamespaceListerExpansion interface{}
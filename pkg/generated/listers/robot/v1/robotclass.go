@@ -0,0 +1,69 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "robot-operator/pkg/apis/robot/v1"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// RobotClassLister helps list RobotClasses.
+// All objects returned here must be treated as read-only.
+type RobotClassLister interface {
+	// List lists all RobotClasses in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.RobotClass, err error)
+	// Get retrieves the RobotClass from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.RobotClass, error)
+	RobotClassListerExpansion
+}
+
+// robotClassLister implements the RobotClassLister interface.
+type robotClassLister struct {
+	indexer cache.Indexer
+}
+
+// NewRobotClassLister returns a new RobotClassLister.
+func NewRobotClassLister(indexer cache.Indexer) RobotClassLister {
+	return &robotClassLister{indexer: indexer}
+}
+
+// List lists all RobotClasses in the indexer.
+func (s *robotClassLister) List(selector labels.Selector) (ret []*v1.RobotClass, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.RobotClass))
+	})
+	return ret, err
+}
+
+// Get retrieves the RobotClass from the index for a given name.
+func (s *robotClassLister) Get(name string) (*v1.RobotClass, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("robotclass"), name)
+	}
+	return obj.(*v1.RobotClass), nil
+}
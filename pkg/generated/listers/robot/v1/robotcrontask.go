@@ -0,0 +1,100 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "robot-operator/pkg/apis/robot/v1"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// RobotCronTaskLister helps list RobotCronTasks.
+// All objects returned here must be treated as read-only.
+type RobotCronTaskLister interface {
+	// List lists all RobotCronTasks in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.RobotCronTask, err error)
+	// RobotCronTasks returns an object that can list and get RobotCronTasks.
+	RobotCronTasks(namespace string) RobotCronTaskNamespaceLister
+	RobotCronTaskListerExpansion
+}
+
+// robotCronTaskLister implements the RobotCronTaskLister interface.
+type robotCronTaskLister struct {
+	indexer cache.Indexer
+}
+
+// NewRobotCronTaskLister returns a new RobotCronTaskLister.
+func NewRobotCronTaskLister(indexer cache.Indexer) RobotCronTaskLister {
+	return &robotCronTaskLister{indexer: indexer}
+}
+
+// List lists all RobotCronTasks in the indexer.
+func (s *robotCronTaskLister) List(selector labels.Selector) (ret []*v1.RobotCronTask, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.RobotCronTask))
+	})
+	return ret, err
+}
+
+// RobotCronTasks returns an object that can list and get RobotCronTasks.
+func (s *robotCronTaskLister) RobotCronTasks(namespace string) RobotCronTaskNamespaceLister {
+	return robotCronTaskNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// RobotCronTaskNamespaceLister helps list and get RobotCronTasks.
+// All objects returned here must be treated as read-only.
+type RobotCronTaskNamespaceLister interface {
+	// List lists all RobotCronTasks in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.RobotCronTask, err error)
+	// Get retrieves the RobotCronTask from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.RobotCronTask, error)
+	RobotCronTaskNamespaceListerExpansion
+}
+
+// robotCronTaskNamespaceLister implements the RobotCronTaskNamespaceLister
+// interface.
+type robotCronTaskNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all RobotCronTasks in the indexer for a given namespace.
+func (s robotCronTaskNamespaceLister) List(selector labels.Selector) (ret []*v1.RobotCronTask, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.RobotCronTask))
+	})
+	return ret, err
+}
+
+// Get retrieves the RobotCronTask from the indexer for a given namespace and name.
+func (s robotCronTaskNamespaceLister) Get(name string) (*v1.RobotCronTask, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("robotcrontask"), name)
+	}
+	return obj.(*v1.RobotCronTask), nil
+}
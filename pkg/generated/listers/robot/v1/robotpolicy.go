@@ -0,0 +1,100 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "robot-operator/pkg/apis/robot/v1"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// RobotPolicyLister helps list RobotPolicies.
+// All objects returned here must be treated as read-only.
+type RobotPolicyLister interface {
+	// List lists all RobotPolicies in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.RobotPolicy, err error)
+	// RobotPolicies returns an object that can list and get RobotPolicies.
+	RobotPolicies(namespace string) RobotPolicyNamespaceLister
+	RobotPolicyListerExpansion
+}
+
+// robotPolicyLister implements the RobotPolicyLister interface.
+type robotPolicyLister struct {
+	indexer cache.Indexer
+}
+
+// NewRobotPolicyLister returns a new RobotPolicyLister.
+func NewRobotPolicyLister(indexer cache.Indexer) RobotPolicyLister {
+	return &robotPolicyLister{indexer: indexer}
+}
+
+// List lists all RobotPolicies in the indexer.
+func (s *robotPolicyLister) List(selector labels.Selector) (ret []*v1.RobotPolicy, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.RobotPolicy))
+	})
+	return ret, err
+}
+
+// RobotPolicies returns an object that can list and get RobotPolicies.
+func (s *robotPolicyLister) RobotPolicies(namespace string) RobotPolicyNamespaceLister {
+	return robotPolicyNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// RobotPolicyNamespaceLister helps list and get RobotPolicies.
+// All objects returned here must be treated as read-only.
+type RobotPolicyNamespaceLister interface {
+	// List lists all RobotPolicies in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.RobotPolicy, err error)
+	// Get retrieves the RobotPolicy from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.RobotPolicy, error)
+	RobotPolicyNamespaceListerExpansion
+}
+
+// robotPolicyNamespaceLister implements the RobotPolicyNamespaceLister
+// interface.
+type robotPolicyNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all RobotPolicies in the indexer for a given namespace.
+func (s robotPolicyNamespaceLister) List(selector labels.Selector) (ret []*v1.RobotPolicy, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.RobotPolicy))
+	})
+	return ret, err
+}
+
+// Get retrieves the RobotPolicy from the indexer for a given namespace and name.
+func (s robotPolicyNamespaceLister) Get(name string) (*v1.RobotPolicy, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("robotpolicy"), name)
+	}
+	return obj.(*v1.RobotPolicy), nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "robot-operator/pkg/apis/robot/v1"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// RobotQuotaLister helps list RobotQuotas.
+// All objects returned here must be treated as read-only.
+type RobotQuotaLister interface {
+	// List lists all RobotQuotas in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.RobotQuota, err error)
+	// RobotQuotas returns an object that can list and get RobotQuotas.
+	RobotQuotas(namespace string) RobotQuotaNamespaceLister
+	RobotQuotaListerExpansion
+}
+
+// robotQuotaLister implements the RobotQuotaLister interface.
+type robotQuotaLister struct {
+	indexer cache.Indexer
+}
+
+// NewRobotQuotaLister returns a new RobotQuotaLister.
+func NewRobotQuotaLister(indexer cache.Indexer) RobotQuotaLister {
+	return &robotQuotaLister{indexer: indexer}
+}
+
+// List lists all RobotQuotas in the indexer.
+func (s *robotQuotaLister) List(selector labels.Selector) (ret []*v1.RobotQuota, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.RobotQuota))
+	})
+	return ret, err
+}
+
+// RobotQuotas returns an object that can list and get RobotQuotas.
+func (s *robotQuotaLister) RobotQuotas(namespace string) RobotQuotaNamespaceLister {
+	return robotQuotaNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// RobotQuotaNamespaceLister helps list and get RobotQuotas.
+// All objects returned here must be treated as read-only.
+type RobotQuotaNamespaceLister interface {
+	// List lists all RobotQuotas in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.RobotQuota, err error)
+	// Get retrieves the RobotQuota from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.RobotQuota, error)
+	RobotQuotaNamespaceListerExpansion
+}
+
+// robotQuotaNamespaceLister implements the RobotQuotaNamespaceLister
+// interface.
+type robotQuotaNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all RobotQuotas in the indexer for a given namespace.
+func (s robotQuotaNamespaceLister) List(selector labels.Selector) (ret []*v1.RobotQuota, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.RobotQuota))
+	})
+	return ret, err
+}
+
+// Get retrieves the RobotQuota from the indexer for a given namespace and name.
+func (s robotQuotaNamespaceLister) Get(name string) (*v1.RobotQuota, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("robotquota"), name)
+	}
+	return obj.(*v1.RobotQuota), nil
+}
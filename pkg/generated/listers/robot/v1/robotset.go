@@ -0,0 +1,100 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "robot-operator/pkg/apis/robot/v1"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// RobotSetLister helps list RobotSets.
+// All objects returned here must be treated as read-only.
+type RobotSetLister interface {
+	// List lists all RobotSets in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.RobotSet, err error)
+	// RobotSets returns an object that can list and get RobotSets.
+	RobotSets(namespace string) RobotSetNamespaceLister
+	RobotSetListerExpansion
+}
+
+// robotSetLister implements the RobotSetLister interface.
+type robotSetLister struct {
+	indexer cache.Indexer
+}
+
+// NewRobotSetLister returns a new RobotSetLister.
+func NewRobotSetLister(indexer cache.Indexer) RobotSetLister {
+	return &robotSetLister{indexer: indexer}
+}
+
+// List lists all RobotSets in the indexer.
+func (s *robotSetLister) List(selector labels.Selector) (ret []*v1.RobotSet, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.RobotSet))
+	})
+	return ret, err
+}
+
+// RobotSets returns an object that can list and get RobotSets.
+func (s *robotSetLister) RobotSets(namespace string) RobotSetNamespaceLister {
+	return robotSetNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// RobotSetNamespaceLister helps list and get RobotSets.
+// All objects returned here must be treated as read-only.
+type RobotSetNamespaceLister interface {
+	// List lists all RobotSets in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.RobotSet, err error)
+	// Get retrieves the RobotSet from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.RobotSet, error)
+	RobotSetNamespaceListerExpansion
+}
+
+// robotSetNamespaceLister implements the RobotSetNamespaceLister
+// interface.
+type robotSetNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all RobotSets in the indexer for a given namespace.
+func (s robotSetNamespaceLister) List(selector labels.Selector) (ret []*v1.RobotSet, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.RobotSet))
+	})
+	return ret, err
+}
+
+// Get retrieves the RobotSet from the indexer for a given namespace and name.
+func (s robotSetNamespaceLister) Get(name string) (*v1.RobotSet, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("robotset"), name)
+	}
+	return obj.(*v1.RobotSet), nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "robot-operator/pkg/apis/robot/v1"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// RobotTaskLister helps list RobotTasks.
+// All objects returned here must be treated as read-only.
+type RobotTaskLister interface {
+	// List lists all RobotTasks in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.RobotTask, err error)
+	// RobotTasks returns an object that can list and get RobotTasks.
+	RobotTasks(namespace string) RobotTaskNamespaceLister
+	RobotTaskListerExpansion
+}
+
+// robotTaskLister implements the RobotTaskLister interface.
+type robotTaskLister struct {
+	indexer cache.Indexer
+}
+
+// NewRobotTaskLister returns a new RobotTaskLister.
+func NewRobotTaskLister(indexer cache.Indexer) RobotTaskLister {
+	return &robotTaskLister{indexer: indexer}
+}
+
+// List lists all RobotTasks in the indexer.
+func (s *robotTaskLister) List(selector labels.Selector) (ret []*v1.RobotTask, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.RobotTask))
+	})
+	return ret, err
+}
+
+// RobotTasks returns an object that can list and get RobotTasks.
+func (s *robotTaskLister) RobotTasks(namespace string) RobotTaskNamespaceLister {
+	return robotTaskNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// RobotTaskNamespaceLister helps list and get RobotTasks.
+// All objects returned here must be treated as read-only.
+type RobotTaskNamespaceLister interface {
+	// List lists all RobotTasks in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.RobotTask, err error)
+	// Get retrieves the RobotTask from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.RobotTask, error)
+	RobotTaskNamespaceListerExpansion
+}
+
+// robotTaskNamespaceLister implements the RobotTaskNamespaceLister
+// interface.
+type robotTaskNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all RobotTasks in the indexer for a given namespace.
+func (s robotTaskNamespaceLister) List(selector labels.Selector) (ret []*v1.RobotTask, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.RobotTask))
+	})
+	return ret, err
+}
+
+// Get retrieves the RobotTask from the indexer for a given namespace and name.
+func (s robotTaskNamespaceLister) Get(name string) (*v1.RobotTask, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("robottask"), name)
+	}
+	return obj.(*v1.RobotTask), nil
+}
@@ -0,0 +1,104 @@
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Interface evaluates instant queries against a Prometheus-compatible endpoint.
+type Interface interface {
+	// Query evaluates query and returns its single numeric result.
+	Query(ctx context.Context, query string) (float64, error)
+}
+
+// Client talks to the HTTP API of a Prometheus-compatible server.
+type Client struct {
+	address    string
+	httpClient *http.Client
+}
+
+// NewClient returns a Client for the server listening on address.
+func NewClient(address string) *Client {
+	return &Client{
+		address:    strings.TrimSuffix(address, "/"),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type queryResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+type vectorSample struct {
+	Metric map[string]string `json:"metric"`
+	Value  []interface{}     `json:"value"`
+}
+
+// Query implements Interface. The query must evaluate to a scalar or to a
+// vector holding exactly one sample.
+func (c *Client) Query(ctx context.Context, query string) (float64, error) {
+	u := c.address + "/api/v1/query?" + url.Values{"query": []string{query}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	var qr queryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&qr); err != nil {
+		return 0, fmt.Errorf("decoding response (HTTP %d): %s", resp.StatusCode, err.Error())
+	}
+	if qr.Status != "success" {
+		return 0, fmt.Errorf("query failed: %s: %s", qr.ErrorType, qr.Error)
+	}
+
+	switch qr.Data.ResultType {
+	case "scalar":
+		var sample []interface{}
+		if err := json.Unmarshal(qr.Data.Result, &sample); err != nil {
+			return 0, err
+		}
+		return parseSample(sample)
+	case "vector":
+		var samples []vectorSample
+		if err := json.Unmarshal(qr.Data.Result, &samples); err != nil {
+			return 0, err
+		}
+		if len(samples) != 1 {
+			return 0, fmt.Errorf("expected exactly one sample, got %d", len(samples))
+		}
+		return parseSample(samples[0].Value)
+	default:
+		return 0, fmt.Errorf("unsupported result type %q", qr.Data.ResultType)
+	}
+}
+
+// parseSample parses a [<timestamp>, "<value>"] pair.
+func parseSample(sample []interface{}) (float64, error) {
+	if len(sample) != 2 {
+		return 0, fmt.Errorf("malformed sample %v", sample)
+	}
+	s, ok := sample[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("malformed sample value %v", sample[1])
+	}
+
+	return strconv.ParseFloat(s, 64)
+}
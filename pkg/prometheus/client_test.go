@@ -0,0 +1,94 @@
+package prometheus
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    float64
+		wantErr bool
+	}{
+		{
+			name:   "scalar",
+			status: http.StatusOK,
+			body:   `{"status":"success","data":{"resultType":"scalar","result":[1600000000,"0.25"]}}`,
+			want:   0.25,
+		},
+		{
+			name:   "single sample vector",
+			status: http.StatusOK,
+			body:   `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"job":"robot"},"value":[1600000000,"42"]}]}}`,
+			want:   42,
+		},
+		{
+			name:    "error status",
+			status:  http.StatusBadRequest,
+			body:    `{"status":"error","errorType":"bad_data","error":"parse error"}`,
+			wantErr: true,
+		},
+		{
+			name:    "not json",
+			status:  http.StatusBadGateway,
+			body:    `bad gateway`,
+			wantErr: true,
+		},
+		{
+			name:    "empty vector",
+			status:  http.StatusOK,
+			body:    `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+			wantErr: true,
+		},
+		{
+			name:    "multiple samples",
+			status:  http.StatusOK,
+			body:    `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1600000000,"1"]},{"metric":{},"value":[1600000000,"2"]}]}}`,
+			wantErr: true,
+		},
+		{
+			name:    "matrix",
+			status:  http.StatusOK,
+			body:    `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var query string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v1/query" {
+					t.Errorf("unexpected path %q", r.URL.Path)
+				}
+				query = r.URL.Query().Get("query")
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			got, err := NewClient(server.URL+"/").Query(context.Background(), `sum(rate(errors[5m]))`)
+			if query != `sum(rate(errors[5m]))` {
+				t.Errorf("server got query %q", query)
+			}
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}